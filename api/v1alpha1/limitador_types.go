@@ -19,13 +19,18 @@ package v1alpha1
 import (
 	"math"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	"github.com/kuadrant/limitador-operator/pkg/helpers"
 )
@@ -35,10 +40,26 @@ const (
 	DefaultServiceGRPCPort int32 = 8081
 	DefaultReplicas        int32 = 1
 
+	DefaultLimitsRolloutCanaryReplicas int32 = 1
+	DefaultLimitsRolloutBakeTime             = 5 * time.Minute
+
 	PodAnnotationConfigMapResourceVersion string = "limits-cm-resource-version"
+	PodAnnotationStorageConfigHash        string = "storage-config-hash"
+
+	// Finalizer releasing the storage retained after the deletion of the Limitador object
+	LimitadorFinalizer string = "limitador.kuadrant.io/storage"
+
+	// DefaultedImageAnnotation records the image written into spec.image by the defaulting webhook,
+	// telling it apart from an image set by the user, so it follows the limitador image of the operator
+	DefaultedImageAnnotation string = "limitador.kuadrant.io/defaulted-image"
 
 	// Status conditions
-	StatusConditionReady string = "Ready"
+	StatusConditionReady        string = "Ready"
+	StatusConditionLimitsValid  string = "LimitsValid"
+	StatusConditionLimitsSynced string = "LimitsSynced"
+	StatusConditionMonitoring   string = "Monitoring"
+	// StatusConditionStorageConfigDrift is true while some storage config cannot be applied to the existing volumes
+	StatusConditionStorageConfigDrift string = "StorageConfigDrift"
 )
 
 var (
@@ -69,9 +90,59 @@ type LimitadorSpec struct {
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// TopologySpreadConstraints of the limitador pods.
+	// Constraints without labelSelector select the pods of this Limitador instance.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// ServiceAccountName is the name of the ServiceAccount used to run the limitador pods.
+	// The ServiceAccount must exist in the namespace of the Limitador CR.
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// PodSecurityContext holds the pod-level security attributes of the limitador pods
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext holds the security attributes of the limitador container
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// PodTemplate holds the metadata added to the limitador pods
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+
+	// Service customizes the Service exposing the limitador pods
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
+	// Probes overrides the health probes of the limitador container
+	// +optional
+	Probes *Probes `json:"probes,omitempty"`
+
+	// CommonLabels are added to every object owned by the Limitador CR, including the limitador pods.
+	// They do not override the labels set by the operator.
+	// +kubebuilder:validation:XValidation:rule="!('app' in self) && !('limitador-resource' in self)",message="labels app and limitador-resource are reserved"
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
 	// +optional
 	Replicas *int `json:"replicas,omitempty"`
 
+	// Autoscaling makes the operator manage a HorizontalPodAutoscaler scaling the limitador deployment.
+	// Mutually exclusive with replicas.
+	// +optional
+	Autoscaling *Autoscaling `json:"autoscaling,omitempty"`
+
 	// [Deprecated] Use spec.image instead.
 	//  Docker tag used as limitador image. The repo is hardcoded to quay.io/kuadrant/limitador
 	// +deprecated
@@ -93,9 +164,36 @@ type LimitadorSpec struct {
 	// +optional
 	Tracing *Tracing `json:"tracing,omitempty"`
 
+	// NetworkPolicy makes the operator manage a NetworkPolicy restricting the traffic of the limitador pods
+	// +optional
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// Monitoring configures the Prometheus monitoring of the limitador pods
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
 	// +optional
 	Limits []RateLimit `json:"limits,omitempty"`
 
+	// LimitsFrom references ConfigMap and Secret keys holding limits in the limitador format.
+	// Their limits are loaded after the inline limits, in the order of the references.
+	// +optional
+	LimitsFrom []LimitsSource `json:"limitsFrom,omitempty"`
+
+	// LimitsRollout stages the rollout of the limits changes. Canary pods load the new limits first,
+	// the other pods load them once the canary pods stayed ready for the bake time.
+	// Not supported with disk storage.
+	// +optional
+	LimitsRollout *LimitsRollout `json:"limitsRollout,omitempty"`
+
+	// AllowedRateLimitDefinitionNamespaces lists the namespaces, besides the namespace of the Limitador CR,
+	// whose RateLimitDefinition objects may add limits to this Limitador instance.
+	// +listType=map
+	// +listMapKey=namespace
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	AllowedRateLimitDefinitionNamespaces []AllowedRateLimitDefinitionNamespace `json:"allowedRateLimitDefinitionNamespaces,omitempty"`
+
 	// +optional
 	PodDisruptionBudget *PodDisruptionBudgetType `json:"pdb,omitempty"`
 
@@ -122,7 +220,10 @@ type LimitadorSpec struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Limitador Ready",priority=2
+//+kubebuilder:printcolumn:name="Limits",type=integer,JSONPath=`.status.limits.total`,description="Number of limits"
+//+kubebuilder:printcolumn:name="Acknowledged",type=integer,JSONPath=`.status.limits.acknowledgedReplicas`,description="Pods running the current limits"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Limitador is the Schema for the limitadors API
@@ -132,6 +233,9 @@ type Limitador struct {
 
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:rule="(!has(self.storage) || !has(self.storage.disk)) || (!has(self.replicas) || self.replicas < 2)",message="disk storage does not allow multiple replicas"
+	// +kubebuilder:validation:XValidation:rule="(!has(self.storage) || !has(self.storage.disk)) || !has(self.autoscaling)",message="disk storage does not allow autoscaling"
+	// +kubebuilder:validation:XValidation:rule="!(has(self.replicas) && has(self.autoscaling))",message="replicas and autoscaling are mutually exclusive"
+	// +kubebuilder:validation:XValidation:rule="(!has(self.storage) || !has(self.storage.disk)) || !has(self.limitsRollout)",message="disk storage does not allow limits rollout"
 	Spec   LimitadorSpec   `json:"spec,omitempty"`
 	Status LimitadorStatus `json:"status,omitempty"`
 }
@@ -156,6 +260,61 @@ func (l *Limitador) HTTPPort() int32 {
 	return *l.Spec.Listener.HTTP.Port
 }
 
+// ServiceType returns the type of the limitador Service and whether the Service is headless
+func (l *Limitador) ServiceType() (corev1.ServiceType, bool) {
+	if l.Spec.Service == nil || l.Spec.Service.Type == nil {
+		return corev1.ServiceTypeClusterIP, true
+	}
+
+	return *l.Spec.Service.Type, false
+}
+
+// MonitorKind returns the kind of the Prometheus monitor, nil when monitoring is not enabled
+func (l *Limitador) MonitorKind() *MonitorKind {
+	if l.Spec.Monitoring == nil || l.Spec.Monitoring.ServiceMonitor == nil {
+		return nil
+	}
+
+	return ptr.To(ptr.Deref(l.Spec.Monitoring.ServiceMonitor.Kind, MonitorKindServiceMonitor))
+}
+
+func (l *Limitador) ProbeType() ProbeType {
+	if l.Spec.Probes == nil || l.Spec.Probes.Type == nil {
+		return ProbeTypeHTTP
+	}
+
+	return *l.Spec.Probes.Type
+}
+
+func (l *Limitador) PodTemplateLabels() map[string]string {
+	if l.Spec.PodTemplate == nil {
+		return nil
+	}
+
+	return l.Spec.PodTemplate.Metadata.Labels
+}
+
+func (l *Limitador) PodTemplateAnnotations() map[string]string {
+	if l.Spec.PodTemplate == nil {
+		return nil
+	}
+
+	return l.Spec.PodTemplate.Metadata.Annotations
+}
+
+// IsImageDefaulted tells whether spec.image was written by the defaulting webhook, rather than set by the user
+func (l *Limitador) IsImageDefaulted() bool {
+	return l.Spec.Image != nil && l.Annotations[DefaultedImageAnnotation] == *l.Spec.Image
+}
+
+func (l *Limitador) ManagedRedis() *ManagedRedis {
+	if l.Spec.Storage == nil || l.Spec.Storage.Redis == nil {
+		return nil
+	}
+
+	return l.Spec.Storage.Redis.Managed
+}
+
 func (l *Limitador) Limits() []RateLimit {
 	if l.Spec.Limits == nil {
 		return make([]RateLimit, 0)
@@ -227,12 +386,65 @@ type Storage struct {
 
 	// +optional
 	Disk *DiskSpec `json:"disk,omitempty"`
+
+	// Memory stores the counters in memory, which is the default. The other storages take precedence over it.
+	// +optional
+	Memory *InMemory `json:"memory,omitempty"`
 }
 
+// InMemory stores the counters in the memory of the limitador pods, hence they are lost when the pods restart
+type InMemory struct{}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.managed) || !(has(self.configSecretRef) || has(self.tls))",message="managed is mutually exclusive with configSecretRef and tls"
 type Redis struct {
 	// +ConfigSecretRef refers to the secret holding the URL for Redis.
 	// +optional
 	ConfigSecretRef *corev1.LocalObjectReference `json:"configSecretRef,omitempty"`
+
+	// +optional
+	TLS *RedisTLS `json:"tls,omitempty"`
+
+	// Managed makes the operator deploy a single Redis instance for the Limitador CR,
+	// along with the secret holding its URL. Intended for development and small clusters.
+	// +optional
+	Managed *ManagedRedis `json:"managed,omitempty"`
+}
+
+// ManagedRedis contains the options of the Redis instance deployed by the operator
+type ManagedRedis struct {
+	// Image overrides the Redis image
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PVC configures the persistent volume claim holding the Redis data
+	// +optional
+	PVC *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+
+	// RetainPolicy tells whether the persistent volume claim holding the Redis data
+	// is kept when the Limitador object is deleted
+	// +optional
+	RetainPolicy *RetainPolicy `json:"retainPolicy,omitempty"`
+}
+
+// RedisTLS contains the options to connect to Redis over TLS, i.e. using a rediss:// URL
+type RedisTLS struct {
+	// CABundle refers to the CA certificates used to verify the Redis server certificate,
+	// instead of the system trust store.
+	// +optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+}
+
+// CABundleSource refers to a PEM encoded CA bundle stored either in a ConfigMap or in a Secret
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type CABundleSource struct {
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 type RedisCachedOptions struct {
@@ -258,6 +470,9 @@ type RedisCached struct {
 	// +optional
 	ConfigSecretRef *corev1.LocalObjectReference `json:"configSecretRef,omitempty"`
 
+	// +optional
+	TLS *RedisTLS `json:"tls,omitempty"`
+
 	// +optional
 	Options *RedisCachedOptions `json:"options,omitempty"`
 }
@@ -292,12 +507,29 @@ const (
 	DiskOptimizeTypeDisk       DiskOptimizeType = "disk"
 )
 
+// RetainPolicy defines what happens to the persistent volume claim of the storage
+// when the Limitador object is deleted
+// +kubebuilder:validation:Enum=Retain;Delete
+type RetainPolicy string
+
+const (
+	// RetainPolicyRetain keeps the persistent volume claim, releasing it from the Limitador object
+	RetainPolicyRetain RetainPolicy = "Retain"
+	// RetainPolicyDelete deletes the persistent volume claim along with the Limitador object
+	RetainPolicyDelete RetainPolicy = "Delete"
+)
+
 type DiskSpec struct {
 	// +optional
 	PVC *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
 
 	// +optional
 	Optimize *DiskOptimizeType `json:"optimize,omitempty"`
+
+	// RetainPolicy tells whether the persistent volume claim holding the counters
+	// is kept when the Limitador object is deleted
+	// +optional
+	RetainPolicy *RetainPolicy `json:"retainPolicy,omitempty"`
 }
 
 type Listener struct {
@@ -310,9 +542,70 @@ type Listener struct {
 type TransportProtocol struct {
 	// +optional
 	Port *int32 `json:"port,omitempty"`
-	// We could describe TLS within this type
 }
 
+// LimitsSource references a key of a ConfigMap or a Secret of the Limitador namespace
+// holding a list of limits in the limitador format
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef and secretKeyRef must be set"
+type LimitsSource struct {
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// LimitsRollout configures the staged rollout of the limits changes
+type LimitsRollout struct {
+	// CanaryReplicas is the number of canary pods loading the new limits first.
+	// They run next to the limitador pods for the time of the rollout. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CanaryReplicas *int32 `json:"canaryReplicas,omitempty"`
+
+	// BakeTime is how long the canary pods must stay ready before the other pods load the new limits.
+	// The canary pods have the same time to get ready. Defaults to 5m.
+	// +optional
+	BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+}
+
+// AllowedRateLimitDefinitionNamespace grants the RateLimitDefinition objects of a namespace
+// to add limits of some limit namespaces
+type AllowedRateLimitDefinitionNamespace struct {
+	// Namespace holding the RateLimitDefinition objects
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// LimitNamespaces lists the values the namespace field of the limits may take
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	LimitNamespaces []string `json:"limitNamespaces"`
+}
+
+func (r *LimitsRollout) GetCanaryReplicas() int32 {
+	return ptr.Deref(r.CanaryReplicas, DefaultLimitsRolloutCanaryReplicas)
+}
+
+func (r *LimitsRollout) GetBakeTime() time.Duration {
+	if r.BakeTime == nil {
+		return DefaultLimitsRolloutBakeTime
+	}
+
+	return r.BakeTime.Duration
+}
+
+// LimitMode tells whether limitador rejects the requests over the limit
+// +kubebuilder:validation:Enum=enforce;shadow
+type LimitMode string
+
+const (
+	// LimitModeEnforce limits reject the requests over the limit
+	LimitModeEnforce LimitMode = "enforce"
+	// LimitModeShadow limits count the requests but never reject them
+	LimitModeShadow LimitMode = "shadow"
+)
+
 // RateLimit defines the desired Limitador limit
 type RateLimit struct {
 	Conditions []string `json:"conditions"`
@@ -321,6 +614,16 @@ type RateLimit struct {
 	Seconds    int      `json:"seconds"`
 	Variables  []string `json:"variables"`
 	Name       string   `json:"name,omitempty"`
+	// Mode of the limit, enforce when not set.
+	// Shadow limits are loaded by limitador with a maximum value never reached.
+	// The operator reports the hits of the shadow limits in its metrics.
+	// +optional
+	Mode LimitMode `json:"mode,omitempty"`
+}
+
+// IsShadow tells whether the limit is in shadow mode
+func (r RateLimit) IsShadow() bool {
+	return r.Mode == LimitModeShadow
 }
 
 // LimitadorStatus defines the observed state of Limitador
@@ -340,9 +643,89 @@ type LimitadorStatus struct {
 	// Service provides information about the service exposing limitador API
 	// +optional
 	Service *LimitadorService `json:"service,omitempty"`
+
+	// Limits summarizes the limits loaded in the limits ConfigMap and their rollout to the pods
+	// +optional
+	Limits *LimitsStatus `json:"limits,omitempty"`
+
+	// LimitsRollout reports the staged rollout of the limits in progress, or rolled back
+	// +optional
+	LimitsRollout *LimitsRolloutStatus `json:"limitsRollout,omitempty"`
+}
+
+// LimitsRolloutPhase is the phase of a staged rollout of the limits
+type LimitsRolloutPhase string
+
+const (
+	// LimitsRolloutPhaseCanary means the canary pods are getting ready with the new limits
+	LimitsRolloutPhaseCanary LimitsRolloutPhase = "Canary"
+	// LimitsRolloutPhaseBaking means the canary pods are ready and baking the new limits
+	LimitsRolloutPhaseBaking LimitsRolloutPhase = "Baking"
+	// LimitsRolloutPhaseRolledBack means the canary pods were not ready, the new limits were not loaded by the other pods
+	LimitsRolloutPhaseRolledBack LimitsRolloutPhase = "RolledBack"
+)
+
+type LimitsRolloutStatus struct {
+	Phase LimitsRolloutPhase `json:"phase"`
+
+	// ConfigHash is the SHA-256 hash of the limits config rolled out
+	ConfigHash string `json:"configHash"`
+
+	// StartTime is the time the canary pods got the limits config
+	StartTime metav1.Time `json:"startTime"`
+
+	// BakeStartTime is the time every canary pod was ready
+	// +optional
+	BakeStartTime *metav1.Time `json:"bakeStartTime,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type LimitsStatus struct {
+	// Total number of limits in the limits ConfigMap, including the ones from RateLimitDefinition objects
+	Total int32 `json:"total"`
+
+	// Shadow is the number of limits in shadow mode, counted but never enforced
+	// +optional
+	Shadow int32 `json:"shadow,omitempty"`
+
+	// Namespaces reports the number of limits per limit namespace
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Namespaces []NamespaceLimitsStatus `json:"namespaces,omitempty"`
+
+	// ConfigHash is the SHA-256 hash of the rendered limits config file
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// ConfigMapResourceVersion is the resource version of the limits ConfigMap
+	// +optional
+	ConfigMapResourceVersion string `json:"configMapResourceVersion,omitempty"`
+
+	// AcknowledgedResourceVersion is the resource version of the limits ConfigMap acknowledged by every pod.
+	// Empty while the pods have not acknowledged the same version.
+	// +optional
+	AcknowledgedResourceVersion string `json:"acknowledgedResourceVersion,omitempty"`
+
+	// AcknowledgedReplicas is the number of pods that acknowledged the current limits ConfigMap resource version
+	// +optional
+	AcknowledgedReplicas int32 `json:"acknowledgedReplicas,omitempty"`
+
+	// LastUpdateTime is the last time the rendered limits config changed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type NamespaceLimitsStatus struct {
+	Namespace string `json:"namespace"`
+	Count     int32  `json:"count"`
 }
 
 type LimitadorService struct {
+	// Host is the cluster DNS name of the Service or, for LoadBalancer Services,
+	// the ingress address of the load balancer once provisioned
 	Host  string `json:"host,omitempty"`
 	Ports Ports  `json:"ports,omitempty"`
 }
@@ -356,6 +739,179 @@ type Tracing struct {
 	Endpoint string `json:"endpoint"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.externalTrafficPolicy) || (has(self.type) && self.type != 'ClusterIP')",message="externalTrafficPolicy requires a NodePort or LoadBalancer service"
+type ServiceSpec struct {
+	// Type of the Service. When not set, the Service is a headless ClusterIP Service.
+	// Switching from or to a headless Service recreates the Service.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type *corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations added to the Service, e.g. cloud provider load balancer settings
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// TrafficDistribution expresses a preference for how traffic is routed to the limitador pods,
+	// e.g. PreferClose for topology aware routing
+	// +optional
+	TrafficDistribution *string `json:"trafficDistribution,omitempty"`
+
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy *corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+}
+
+type NetworkPolicySpec struct {
+	// GRPC lists the sources allowed to reach the gRPC (RLS) listener.
+	// When not set, the gRPC listener accepts traffic from any source.
+	// +optional
+	GRPC []networkingv1.NetworkPolicyPeer `json:"grpc,omitempty"`
+
+	// HTTP lists the sources allowed to reach the HTTP listener.
+	// When not set, the HTTP listener accepts traffic from any source.
+	// The operator pods are always allowed, as they query the limits loaded by the limitador pods.
+	// +optional
+	HTTP []networkingv1.NetworkPolicyPeer `json:"http,omitempty"`
+
+	// Egress restricts the egress traffic of the limitador pods to DNS resolution,
+	// the Redis storage and the tracing endpoint
+	// +optional
+	Egress bool `json:"egress,omitempty"`
+}
+
+type Monitoring struct {
+	// ServiceMonitor makes the operator manage a Prometheus Operator monitor scraping
+	// the limitador metrics endpoint. Requires the monitoring.coreos.com CRDs.
+	// +optional
+	ServiceMonitor *ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+type MonitorKind string
+
+const (
+	MonitorKindServiceMonitor MonitorKind = "ServiceMonitor"
+	MonitorKindPodMonitor     MonitorKind = "PodMonitor"
+)
+
+type ServiceMonitorSpec struct {
+	// Kind of the monitor, scraping the pods through the limitador Service or directly.
+	// Defaults to ServiceMonitor.
+	// +optional
+	Kind *MonitorKind `json:"kind,omitempty"`
+
+	// Interval at which metrics are scraped. Defaults to the scrape interval of Prometheus.
+	// +optional
+	Interval *monitoringv1.Duration `json:"interval,omitempty"`
+
+	// Labels added to the monitor, e.g. to match the monitor selector of the Prometheus instance.
+	// They do not override the labels set by the operator.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Relabelings applied to the scraped targets before ingestion
+	// +optional
+	Relabelings []monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=HTTP;GRPC
+type ProbeType string
+
+const (
+	// ProbeTypeHTTP probes GET /status on the HTTP listener
+	ProbeTypeHTTP ProbeType = "HTTP"
+	// ProbeTypeGRPC probes the gRPC health service on the RLS listener
+	ProbeTypeGRPC ProbeType = "GRPC"
+)
+
+type Probes struct {
+	// Type of the probes. HTTP probes GET /status on the HTTP listener, GRPC probes the
+	// gRPC health service on the RLS listener. Defaults to HTTP.
+	// +optional
+	Type *ProbeType `json:"type,omitempty"`
+
+	// Liveness overrides the timings of the liveness probe
+	// +optional
+	Liveness *ProbeTimings `json:"liveness,omitempty"`
+
+	// Readiness overrides the timings of the readiness probe
+	// +optional
+	Readiness *ProbeTimings `json:"readiness,omitempty"`
+
+	// Startup adds a startup probe, holding off the liveness and readiness probes until it succeeds.
+	// Useful for instances with a long warm-up.
+	// +optional
+	Startup *ProbeTimings `json:"startup,omitempty"`
+}
+
+// ProbeTimings overrides the timings of a probe. Unset fields keep the operator defaults.
+type ProbeTimings struct {
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+type PodTemplate struct {
+	// +optional
+	Metadata PodTemplateMetadata `json:"metadata,omitempty"`
+}
+
+type PodTemplateMetadata struct {
+	// Labels added to the limitador pods. They do not override the labels set by the operator.
+	// +kubebuilder:validation:XValidation:rule="!('app' in self) && !('limitador-resource' in self)",message="labels app and limitador-resource are reserved"
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the limitador pods. They do not override the annotations set by the operator.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type Autoscaling struct {
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization, over all the pods,
+	// represented as a percentage of the requested CPU
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the target average memory utilization, over all the pods,
+	// represented as a percentage of the requested memory
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics are additional metrics, e.g. custom or external metrics, used to compute the desired replica count.
+	// When no metric nor target is set, the HorizontalPodAutoscaler defaults to 80% average CPU utilization.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="!(has(self.maxUnavailable) && has(self.minAvailable))",message="pdb spec invalid, maxUnavailable and minAvailable are mutually exclusive"
 type PodDisruptionBudgetType struct {
 	// An eviction is allowed if at most "maxUnavailable" limitador pods
@@ -394,6 +950,18 @@ func (s *LimitadorStatus) Equals(other *LimitadorStatus, logger logr.Logger) boo
 		return false
 	}
 
+	if !reflect.DeepEqual(s.Limits, other.Limits) {
+		diff := cmp.Diff(s.Limits, other.Limits)
+		logger.V(1).Info("status limits not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.LimitsRollout, other.LimitsRollout) {
+		diff := cmp.Diff(s.LimitsRollout, other.LimitsRollout)
+		logger.V(1).Info("status limits rollout not equal", "difference", diff)
+		return false
+	}
+
 	return true
 }
 
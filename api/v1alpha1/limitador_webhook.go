@@ -0,0 +1,315 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kuadrant/limitador-operator/pkg/limitador/conditions"
+)
+
+// limitadorRepository is the repository of the image set by the deprecated spec.version field
+const limitadorRepository = "quay.io/kuadrant/limitador"
+
+// SetupWebhookWithManager registers the Limitador webhooks in the manager. The conversion webhook
+// is registered too, when the other API versions of the Limitador kind are added to the manager scheme.
+// The defaulting webhook sets the image to defaultImage when neither the image nor the version are set.
+func (l *Limitador) SetupWebhookWithManager(mgr ctrl.Manager, defaultImage string) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(l).
+		WithDefaulter(&LimitadorDefaulter{Image: defaultImage}).
+		WithValidator(&LimitadorValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-limitador-kuadrant-io-v1alpha1-limitador,mutating=true,failurePolicy=fail,sideEffects=None,groups=limitador.kuadrant.io,resources=limitadors,verbs=create;update,versions=v1alpha1,name=mlimitador.kb.io,admissionReviewVersions=v1
+
+// LimitadorDefaulter writes the defaults applied by the operator into the spec of the Limitador objects,
+// so the objects show the effective configuration. The defaulted image is annotated,
+// so it follows the limitador image of the operator when the operator is upgraded.
+// +kubebuilder:object:generate=false
+type LimitadorDefaulter struct {
+	// Image is the limitador image used when neither the image nor the deprecated version are set
+	Image string
+}
+
+var _ admission.CustomDefaulter = &LimitadorDefaulter{}
+
+func (d *LimitadorDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	limitadorObj, ok := obj.(*Limitador)
+	if !ok {
+		return fmt.Errorf("expected a Limitador object but got %T", obj)
+	}
+
+	limitadorObj.applyDefaults(d.Image)
+	return nil
+}
+
+// applyDefaults sets the ports, replicas, resources, image, storage and limits rollout settings the operator defaults to.
+// The replicas are left to the HorizontalPodAutoscaler when autoscaling is enabled.
+func (l *Limitador) applyDefaults(defaultImage string) {
+	if l.Spec.Listener == nil {
+		l.Spec.Listener = &Listener{}
+	}
+	if l.Spec.Listener.HTTP == nil {
+		l.Spec.Listener.HTTP = &TransportProtocol{}
+	}
+	if l.Spec.Listener.HTTP.Port == nil {
+		l.Spec.Listener.HTTP.Port = ptr.To(DefaultServiceHTTPPort)
+	}
+	if l.Spec.Listener.GRPC == nil {
+		l.Spec.Listener.GRPC = &TransportProtocol{}
+	}
+	if l.Spec.Listener.GRPC.Port == nil {
+		l.Spec.Listener.GRPC.Port = ptr.To(DefaultServiceGRPCPort)
+	}
+
+	if l.Spec.Autoscaling != nil {
+		l.Spec.Replicas = nil
+	} else if l.Spec.Replicas == nil {
+		l.Spec.Replicas = ptr.To(int(DefaultReplicas))
+	}
+
+	if l.Spec.ResourceRequirements == nil {
+		l.Spec.ResourceRequirements = defaultResourceRequirements.DeepCopy()
+	}
+
+	// The defaulted image is resolved again, as the version or the image of the operator may have changed
+	if l.Spec.Image == nil || l.IsImageDefaulted() {
+		image := defaultImage
+		if l.Spec.Version != nil {
+			image = fmt.Sprintf("%s:%s", limitadorRepository, *l.Spec.Version)
+		}
+		l.setDefaultedImage(image)
+	} else {
+		l.setDefaultedImage("")
+	}
+
+	if l.Spec.Storage == nil {
+		l.Spec.Storage = &Storage{}
+	}
+	if l.Spec.Storage.Redis == nil && l.Spec.Storage.RedisCached == nil && l.Spec.Storage.Disk == nil {
+		l.Spec.Storage.Memory = &InMemory{}
+	} else {
+		l.Spec.Storage.Memory = nil
+	}
+	if l.Spec.Storage.Disk != nil && l.Spec.Storage.Disk.Optimize == nil {
+		l.Spec.Storage.Disk.Optimize = ptr.To(DiskOptimizeTypeThroughput)
+	}
+
+	if rollout := l.Spec.LimitsRollout; rollout != nil {
+		rollout.CanaryReplicas = ptr.To(rollout.GetCanaryReplicas())
+		rollout.BakeTime = &metav1.Duration{Duration: rollout.GetBakeTime()}
+	}
+}
+
+// setDefaultedImage writes the defaulted image into spec.image and the annotation telling it apart.
+// An empty image only removes the annotation, keeping the image set by the user.
+func (l *Limitador) setDefaultedImage(image string) {
+	if image == "" {
+		if _, ok := l.Annotations[DefaultedImageAnnotation]; ok {
+			delete(l.Annotations, DefaultedImageAnnotation)
+			if len(l.Annotations) == 0 {
+				l.Annotations = nil
+			}
+		}
+		return
+	}
+
+	l.Spec.Image = ptr.To(image)
+	if l.Annotations == nil {
+		l.Annotations = map[string]string{}
+	}
+	l.Annotations[DefaultedImageAnnotation] = image
+}
+
+//+kubebuilder:webhook:path=/validate-limitador-kuadrant-io-v1alpha1-limitador,mutating=false,failurePolicy=fail,sideEffects=None,groups=limitador.kuadrant.io,resources=limitadors,verbs=create;update,versions=v1alpha1,name=vlimitador.kb.io,admissionReviewVersions=v1
+
+// LimitadorValidator rejects Limitador objects whose limits would not be loaded by limitador
+// +kubebuilder:object:generate=false
+type LimitadorValidator struct{}
+
+var _ admission.CustomValidator = &LimitadorValidator{}
+
+func (v *LimitadorValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	limitadorObj, ok := obj.(*Limitador)
+	if !ok {
+		return nil, fmt.Errorf("expected a Limitador object but got %T", obj)
+	}
+
+	return limitadorObj.validate(nil)
+}
+
+func (v *LimitadorValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	limitadorObj, ok := newObj.(*Limitador)
+	if !ok {
+		return nil, fmt.Errorf("expected a Limitador object but got %T", newObj)
+	}
+
+	oldLimitadorObj, ok := oldObj.(*Limitador)
+	if !ok {
+		return nil, fmt.Errorf("expected a Limitador object but got %T", oldObj)
+	}
+
+	return limitadorObj.validate(oldLimitadorObj)
+}
+
+func (v *LimitadorValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate rejects the invalid limits of the object, given the object it replaces on update.
+// The limits admitted before, or created while the webhook was disabled, are not rejected again
+// so the updates leaving them unchanged, like the finalizer updates of the operator, are admitted.
+// Their unreferenced variables are returned as warnings.
+func (l *Limitador) validate(old *Limitador) (admission.Warnings, error) {
+	limitsPath := field.NewPath("spec", "limits")
+	errs := field.ErrorList{}
+	var oldLimits []RateLimit
+	if old != nil {
+		oldLimits = old.Spec.Limits
+	}
+	if old == nil || !equality.Semantic.DeepEqual(old.Spec.Limits, l.Spec.Limits) {
+		errs = ValidateRateLimits(l.Spec.Limits, limitsPath)
+	}
+
+	variableErrs, warnings := ValidateRateLimitVariables(l.Spec.Limits, oldLimits, limitsPath)
+	errs = append(errs, variableErrs...)
+	if len(errs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(GroupVersion.WithKind("Limitador").GroupKind(), l.Name, errs)
+}
+
+// ValidateLimits returns the errors of the inline limits preventing limitador from loading them
+func (l *Limitador) ValidateLimits() field.ErrorList {
+	return ValidateRateLimits(l.Spec.Limits, field.NewPath("spec", "limits"))
+}
+
+// ValidateRateLimits returns the errors of the limits preventing limitador from loading them
+func ValidateRateLimits(limits []RateLimit, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	names := map[string]int{}
+
+	for idx, limit := range limits {
+		limitPath := path.Index(idx)
+
+		if limit.Seconds <= 0 {
+			errs = append(errs, field.Invalid(limitPath.Child("seconds"), limit.Seconds, "must be greater than 0"))
+		}
+
+		if limit.MaxValue <= 0 {
+			errs = append(errs, field.Invalid(limitPath.Child("max_value"), limit.MaxValue, "must be greater than 0"))
+		}
+
+		if strings.TrimSpace(limit.Namespace) == "" {
+			errs = append(errs, field.Required(limitPath.Child("namespace"), "must not be empty"))
+		}
+
+		if limit.Mode != "" && limit.Mode != LimitModeEnforce && limit.Mode != LimitModeShadow {
+			errs = append(errs, field.NotSupported(limitPath.Child("mode"), limit.Mode, []LimitMode{LimitModeEnforce, LimitModeShadow}))
+		}
+
+		if limit.Name != "" {
+			if firstIdx, ok := names[limit.Name]; ok {
+				errs = append(errs, field.Duplicate(limitPath.Child("name"), fmt.Sprintf("%s, already used by %s", limit.Name, path.Index(firstIdx))))
+			} else {
+				names[limit.Name] = idx
+			}
+		}
+
+		for condIdx, condition := range limit.Conditions {
+			if _, err := conditions.Parse(condition); err != nil {
+				errs = append(errs, field.Invalid(limitPath.Child("conditions").Index(condIdx), condition, err.Error()))
+			}
+		}
+
+		for varIdx, variable := range limit.Variables {
+			if strings.TrimSpace(variable) == "" {
+				errs = append(errs, field.Required(limitPath.Child("variables").Index(varIdx), "must not be empty"))
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateRateLimitVariables returns the errors of the variables not referenced by any condition of their limit.
+// Limitador loads them, as they qualify the counters, but they often come from a typo, so they are rejected
+// at admission. The variables already unreferenced in the previous limits of the same namespace are returned
+// as warnings instead, so the objects admitted before keep being updatable. The existing objects are still reconciled.
+func ValidateRateLimitVariables(limits, previousLimits []RateLimit, path *field.Path) (field.ErrorList, admission.Warnings) {
+	errs := field.ErrorList{}
+	var warnings admission.Warnings
+
+	previous := map[string]bool{}
+	for _, limit := range previousLimits {
+		for _, variable := range unreferencedVariables(limit) {
+			previous[limit.Namespace+"/"+variable] = true
+		}
+	}
+
+	for idx, limit := range limits {
+		for varIdx, variable := range limit.Variables {
+			if !slices.Contains(unreferencedVariables(limit), variable) {
+				continue
+			}
+			err := field.Invalid(path.Index(idx).Child("variables").Index(varIdx), variable, "not referenced by any condition")
+			if previous[limit.Namespace+"/"+variable] {
+				warnings = append(warnings, err.Error())
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errs, warnings
+}
+
+// unreferencedVariables returns the variables of the limit not referenced by any of its conditions
+func unreferencedVariables(limit RateLimit) []string {
+	references := []string{}
+	for _, condition := range limit.Conditions {
+		// unparsable conditions are reported by ValidateRateLimits
+		if parsed, err := conditions.Parse(condition); err == nil {
+			references = append(references, parsed.References...)
+		}
+	}
+
+	unreferenced := []string{}
+	for _, variable := range limit.Variables {
+		if strings.TrimSpace(variable) == "" || slices.Contains(references, strings.TrimSpace(variable)) {
+			continue
+		}
+		unreferenced = append(unreferenced, variable)
+	}
+
+	return unreferenced
+}
@@ -0,0 +1,269 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func validRateLimit() RateLimit {
+	return RateLimit{
+		Conditions: []string{"req.method == 'GET'"},
+		MaxValue:   10,
+		Namespace:  "toystore",
+		Seconds:    60,
+		Variables:  []string{},
+		Name:       "get",
+	}
+}
+
+func TestValidateRateLimits(t *testing.T) {
+	path := field.NewPath("spec", "limits")
+
+	t.Run("valid limits", func(subT *testing.T) {
+		errs := ValidateRateLimits([]RateLimit{validRateLimit()}, path)
+		assert.Assert(subT, len(errs) == 0)
+	})
+
+	t.Run("non positive seconds and max value", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Seconds = 0
+		limit.MaxValue = -1
+		errs := ValidateRateLimits([]RateLimit{limit}, path)
+		assert.Assert(subT, len(errs) == 2)
+		assert.Equal(subT, errs[0].Field, "spec.limits[0].seconds")
+		assert.Equal(subT, errs[1].Field, "spec.limits[0].max_value")
+	})
+
+	t.Run("empty namespace", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Namespace = " "
+		errs := ValidateRateLimits([]RateLimit{limit}, path)
+		assert.Assert(subT, len(errs) == 1)
+		assert.Equal(subT, errs[0].Type, field.ErrorTypeRequired)
+		assert.Equal(subT, errs[0].Field, "spec.limits[0].namespace")
+	})
+
+	t.Run("unsupported mode", func(subT *testing.T) {
+		shadow := validRateLimit()
+		shadow.Mode = LimitModeShadow
+		unsupported := validRateLimit()
+		unsupported.Name = "unsupported"
+		unsupported.Mode = "dry-run"
+		errs := ValidateRateLimits([]RateLimit{shadow, unsupported}, path)
+		assert.Assert(subT, len(errs) == 1)
+		assert.Equal(subT, errs[0].Type, field.ErrorTypeNotSupported)
+		assert.Equal(subT, errs[0].Field, "spec.limits[1].mode")
+	})
+
+	t.Run("duplicate names", func(subT *testing.T) {
+		unnamed := validRateLimit()
+		unnamed.Name = ""
+		errs := ValidateRateLimits([]RateLimit{validRateLimit(), unnamed, unnamed, validRateLimit()}, path)
+		assert.Assert(subT, len(errs) == 1)
+		assert.Equal(subT, errs[0].Type, field.ErrorTypeDuplicate)
+		assert.Equal(subT, errs[0].Field, "spec.limits[3].name")
+	})
+
+	t.Run("unparsable conditions", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Conditions = []string{"", "req.method == 'GET", "(a == 'b'))", "req.method == 'GET' && size(req.path) > 1"}
+		errs := ValidateRateLimits([]RateLimit{limit}, path)
+		assert.Assert(subT, len(errs) == 3)
+		assert.Equal(subT, errs[1].Field, "spec.limits[0].conditions[1]")
+		assert.ErrorContains(subT, errs[1], "1:15:")
+	})
+
+}
+
+func TestValidateRateLimitVariables(t *testing.T) {
+	path := field.NewPath("spec", "limits")
+
+	t.Run("referenced variables", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Conditions = []string{"req.method == 'GET'", "auth.identity.user_id != ''"}
+		limit.Variables = []string{"req.method", "auth.identity.user_id"}
+		errs, warnings := ValidateRateLimitVariables([]RateLimit{limit}, nil, path)
+		assert.Assert(subT, len(errs) == 0)
+		assert.Assert(subT, len(warnings) == 0)
+	})
+
+	t.Run("unreferenced variables are invalid", func(subT *testing.T) {
+		limit := validRateLimit()
+		// user is a substring of the referenced selectors, not one of them
+		limit.Conditions = []string{"req.method == 'GET'", "user_id != ''"}
+		limit.Variables = []string{"req.method", "user", "method"}
+		errs, _ := ValidateRateLimitVariables([]RateLimit{limit}, nil, path)
+		assert.Assert(subT, len(errs) == 2)
+		assert.Equal(subT, errs[0].Type, field.ErrorTypeInvalid)
+		assert.Equal(subT, errs[0].Field, "spec.limits[0].variables[1]")
+		assert.Equal(subT, errs[1].Field, "spec.limits[0].variables[2]")
+	})
+
+	t.Run("unreferenced variables of the previous limits are warnings", func(subT *testing.T) {
+		previous := validRateLimit()
+		previous.Variables = []string{"user_id"}
+		limit := validRateLimit()
+		limit.MaxValue = 20
+		limit.Variables = []string{"user_id", "org_id"}
+		errs, warnings := ValidateRateLimitVariables([]RateLimit{limit}, []RateLimit{previous}, path)
+		assert.Assert(subT, len(errs) == 1)
+		assert.Equal(subT, errs[0].Field, "spec.limits[0].variables[1]")
+		assert.DeepEqual(subT, []string(warnings), []string{
+			`spec.limits[0].variables[0]: Invalid value: "user_id": not referenced by any condition`,
+		})
+	})
+}
+
+func TestLimitadorValidator(t *testing.T) {
+	validator := &LimitadorValidator{}
+
+	t.Run("valid object is accepted", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{Limits: []RateLimit{validRateLimit()}}}
+		_, err := validator.ValidateCreate(context.TODO(), l)
+		assert.NilError(subT, err)
+	})
+
+	t.Run("unreferenced variables are rejected", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Variables = []string{"user_id"}
+		l := &Limitador{Spec: LimitadorSpec{Limits: []RateLimit{limit}}}
+		warnings, err := validator.ValidateCreate(context.TODO(), l)
+		assert.Assert(subT, apierrors.IsInvalid(err))
+		assert.Assert(subT, len(warnings) == 0)
+	})
+
+	t.Run("invalid object is rejected", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Seconds = 0
+		l := &Limitador{Spec: LimitadorSpec{Limits: []RateLimit{limit}}}
+		_, err := validator.ValidateUpdate(context.TODO(), &Limitador{}, l)
+		assert.Assert(subT, apierrors.IsInvalid(err))
+	})
+
+	t.Run("update of an object with unreferenced variables", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Variables = []string{"user_id"}
+		old := &Limitador{Spec: LimitadorSpec{Limits: []RateLimit{limit}}}
+
+		// the finalizer added by the operator
+		l := old.DeepCopy()
+		l.Finalizers = []string{LimitadorFinalizer}
+		warnings, err := validator.ValidateUpdate(context.TODO(), old, l)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(warnings) == 1)
+
+		// a new unreferenced variable
+		l = old.DeepCopy()
+		l.Spec.Limits[0].Variables = append(l.Spec.Limits[0].Variables, "org_id")
+		warnings, err = validator.ValidateUpdate(context.TODO(), old, l)
+		assert.Assert(subT, apierrors.IsInvalid(err))
+		assert.ErrorContains(subT, err, "spec.limits[0].variables[1]")
+		assert.Assert(subT, len(warnings) == 1)
+	})
+
+	t.Run("update leaving invalid limits unchanged is accepted", func(subT *testing.T) {
+		limit := validRateLimit()
+		limit.Seconds = 0
+		old := &Limitador{Spec: LimitadorSpec{Limits: []RateLimit{limit}}}
+		l := old.DeepCopy()
+		l.Finalizers = []string{LimitadorFinalizer}
+		_, err := validator.ValidateUpdate(context.TODO(), old, l)
+		assert.NilError(subT, err)
+
+		l.Spec.Limits[0].MaxValue = 20
+		_, err = validator.ValidateUpdate(context.TODO(), old, l)
+		assert.Assert(subT, apierrors.IsInvalid(err))
+	})
+}
+
+func TestLimitadorDefaulter(t *testing.T) {
+	defaulter := &LimitadorDefaulter{Image: "quay.io/kuadrant/limitador:v2.0.0"}
+
+	t.Run("defaults are written into the spec", func(subT *testing.T) {
+		l := &Limitador{}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.Listener, &Listener{
+			HTTP: &TransportProtocol{Port: ptr.To(DefaultServiceHTTPPort)},
+			GRPC: &TransportProtocol{Port: ptr.To(DefaultServiceGRPCPort)},
+		})
+		assert.DeepEqual(subT, l.Spec.Replicas, ptr.To(1))
+		assert.DeepEqual(subT, l.Spec.ResourceRequirements, defaultResourceRequirements)
+		assert.DeepEqual(subT, l.Spec.Image, ptr.To("quay.io/kuadrant/limitador:v2.0.0"))
+		assert.Equal(subT, l.Annotations[DefaultedImageAnnotation], "quay.io/kuadrant/limitador:v2.0.0")
+		assert.DeepEqual(subT, l.Spec.Storage, &Storage{Memory: &InMemory{}})
+	})
+
+	t.Run("explicit values are kept", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{
+			Listener: &Listener{HTTP: &TransportProtocol{Port: ptr.To(int32(9000))}},
+			Replicas: ptr.To(3),
+			Image:    ptr.To("example.com/limitador:dev"),
+			Storage:  &Storage{Disk: &DiskSpec{Optimize: ptr.To(DiskOptimizeTypeDisk)}},
+		}}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.Listener.HTTP.Port, ptr.To(int32(9000)))
+		assert.DeepEqual(subT, l.Spec.Replicas, ptr.To(3))
+		assert.DeepEqual(subT, l.Spec.Image, ptr.To("example.com/limitador:dev"))
+		assert.Assert(subT, l.Annotations == nil)
+		assert.DeepEqual(subT, l.Spec.Storage.Disk.Optimize, ptr.To(DiskOptimizeTypeDisk))
+		assert.Assert(subT, l.Spec.Storage.Memory == nil)
+	})
+
+	t.Run("deprecated version sets the image", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{Version: ptr.To("v1.6.0")}}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.Version, ptr.To("v1.6.0"))
+		assert.DeepEqual(subT, l.Spec.Image, ptr.To("quay.io/kuadrant/limitador:v1.6.0"))
+		assert.Equal(subT, l.Annotations[DefaultedImageAnnotation], "quay.io/kuadrant/limitador:v1.6.0")
+	})
+
+	t.Run("defaulted image follows the image of the operator", func(subT *testing.T) {
+		l := &Limitador{}
+		assert.NilError(subT, (&LimitadorDefaulter{Image: "quay.io/kuadrant/limitador:v1.0.0"}).Default(context.TODO(), l))
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.Image, ptr.To("quay.io/kuadrant/limitador:v2.0.0"))
+		assert.Equal(subT, l.Annotations[DefaultedImageAnnotation], "quay.io/kuadrant/limitador:v2.0.0")
+	})
+
+	t.Run("image set by the user over the defaulted one", func(subT *testing.T) {
+		l := &Limitador{}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		l.Spec.Image = ptr.To("example.com/limitador:dev")
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.Image, ptr.To("example.com/limitador:dev"))
+		assert.Assert(subT, l.Annotations == nil)
+	})
+
+	t.Run("in-memory storage removed along with another storage", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{Storage: &Storage{Memory: &InMemory{}, Disk: &DiskSpec{}}}}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.Assert(subT, l.Spec.Storage.Memory == nil)
+	})
+
+	t.Run("disk optimization", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{Storage: &Storage{Disk: &DiskSpec{}}}}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.Storage.Disk.Optimize, ptr.To(DiskOptimizeTypeThroughput))
+	})
+
+	t.Run("limits rollout", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{LimitsRollout: &LimitsRollout{CanaryReplicas: ptr.To(int32(2))}}}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.DeepEqual(subT, l.Spec.LimitsRollout, &LimitsRollout{
+			CanaryReplicas: ptr.To(int32(2)),
+			BakeTime:       &metav1.Duration{Duration: DefaultLimitsRolloutBakeTime},
+		})
+	})
+
+	t.Run("replicas are left to the autoscaler", func(subT *testing.T) {
+		l := &Limitador{Spec: LimitadorSpec{Replicas: ptr.To(1), Autoscaling: &Autoscaling{MaxReplicas: 3}}}
+		assert.NilError(subT, defaulter.Default(context.TODO(), l))
+		assert.Assert(subT, l.Spec.Replicas == nil)
+	})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+const (
+	// Status conditions
+	StatusConditionAccepted string = "Accepted"
+)
+
+// LimitadorReference refers to a Limitador object
+type LimitadorReference struct {
+	// Name of the Limitador object
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the Limitador object.
+	// Defaults to the namespace of the referencing object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RateLimitDefinitionSpec defines the desired state of RateLimitDefinition
+type RateLimitDefinitionSpec struct {
+	// LimitadorRef selects the Limitador instance the limits are added to
+	LimitadorRef LimitadorReference `json:"limitadorRef"`
+
+	// +optional
+	Limits []RateLimit `json:"limits,omitempty"`
+}
+
+// RateLimitDefinitionStatus defines the observed state of RateLimitDefinition
+type RateLimitDefinitionStatus struct {
+	// ObservedGeneration reflects the generation of the most recently observed spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions reports whether the limits were added to the Limitador instance.
+	// Known .status.conditions.type are: "Accepted"
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// Limits reports whether each limit of the spec, in the same order, was accepted
+	// +optional
+	Limits []RateLimitStatus `json:"limits,omitempty"`
+}
+
+// RateLimitStatus reports whether a limit was added to the Limitador instance
+type RateLimitStatus struct {
+	// Name of the limit, if any
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	Accepted bool `json:"accepted"`
+
+	// Message explains why the limit was not accepted
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Limitador",type=string,JSONPath=`.spec.limitadorRef.name`
+//+kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+
+// RateLimitDefinition is the Schema for the ratelimitdefinitions API
+type RateLimitDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RateLimitDefinitionSpec   `json:"spec,omitempty"`
+	Status RateLimitDefinitionStatus `json:"status,omitempty"`
+}
+
+func (r *RateLimitDefinition) Limits() []RateLimit {
+	if r.Spec.Limits == nil {
+		return make([]RateLimit, 0)
+	}
+
+	return r.Spec.Limits
+}
+
+// LimitadorKey returns the key of the referenced Limitador object
+func (r *RateLimitDefinition) LimitadorKey() types.NamespacedName {
+	namespace := r.Spec.LimitadorRef.Namespace
+	if namespace == "" {
+		namespace = r.Namespace
+	}
+
+	return types.NamespacedName{Name: r.Spec.LimitadorRef.Name, Namespace: namespace}
+}
+
+//+kubebuilder:object:root=true
+
+// RateLimitDefinitionList contains a list of RateLimitDefinition
+type RateLimitDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RateLimitDefinition `json:"items"`
+}
+
+func (s *RateLimitDefinitionStatus) Equals(other *RateLimitDefinitionStatus, logger logr.Logger) bool {
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("status observedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := helpers.ConditionMarshal(s.Conditions)
+	otherMarshaledJSON, _ := helpers.ConditionMarshal(other.Conditions)
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("status conditions not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Limits, other.Limits) {
+		diff := cmp.Diff(s.Limits, other.Limits)
+		logger.V(1).Info("status limits not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+func init() {
+	SchemeBuilder.Register(&RateLimitDefinition{}, &RateLimitDefinitionList{})
+}
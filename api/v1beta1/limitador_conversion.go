@@ -0,0 +1,531 @@
+/*
+Copyright 2025 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+const (
+	// limitadorRepository is the repository of the image set by the deprecated v1alpha1 spec.version field
+	limitadorRepository = "quay.io/kuadrant/limitador"
+
+	// VersionAnnotation keeps the deprecated v1alpha1 spec.version field of the objects read as v1beta1,
+	// so it is restored when they are converted back to v1alpha1
+	VersionAnnotation = "limitador.kuadrant.io/v1alpha1-version"
+)
+
+var _ conversion.Convertible = &Limitador{}
+
+// ConvertTo converts this Limitador to the hub version (v1alpha1).
+// The deprecated spec.version field is restored from the version annotation. The image it stands for
+// is dropped, unless it was changed.
+func (src *Limitador) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*limitadorv1alpha1.Limitador)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Limitador object but got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Status = statusToV1alpha1(src.Status.DeepCopy())
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = limitadorv1alpha1.LimitadorSpec{
+		Service:      convertPtr(spec.Service, func(s ServiceSpec) limitadorv1alpha1.ServiceSpec { return limitadorv1alpha1.ServiceSpec(s) }),
+		CommonLabels: spec.CommonLabels,
+		Replicas:     spec.Replicas,
+		Autoscaling:  convertPtr(spec.Autoscaling, func(a Autoscaling) limitadorv1alpha1.Autoscaling { return limitadorv1alpha1.Autoscaling(a) }),
+		Listener:     listenerToV1alpha1(spec.Listener),
+		Storage:      storageToV1alpha1(spec.Storage),
+		RateLimitHeaders: convertPtr(spec.RateLimitHeaders, func(r RateLimitHeadersType) limitadorv1alpha1.RateLimitHeadersType {
+			return limitadorv1alpha1.RateLimitHeadersType(r)
+		}),
+		Telemetry: convertPtr(spec.Telemetry, func(t Telemetry) limitadorv1alpha1.Telemetry { return limitadorv1alpha1.Telemetry(t) }),
+		Tracing:   convertPtr(spec.Tracing, func(t Tracing) limitadorv1alpha1.Tracing { return limitadorv1alpha1.Tracing(t) }),
+		NetworkPolicy: convertPtr(spec.NetworkPolicy, func(n NetworkPolicySpec) limitadorv1alpha1.NetworkPolicySpec {
+			return limitadorv1alpha1.NetworkPolicySpec(n)
+		}),
+		Monitoring: monitoringToV1alpha1(spec.Monitoring),
+		PodDisruptionBudget: convertPtr(spec.PodDisruptionBudget, func(p PodDisruptionBudgetType) limitadorv1alpha1.PodDisruptionBudgetType {
+			return limitadorv1alpha1.PodDisruptionBudgetType(p)
+		}),
+		Verbosity:           convertPtr(spec.Verbosity, func(v VerbosityLevel) limitadorv1alpha1.VerbosityLevel { return limitadorv1alpha1.VerbosityLevel(v) }),
+		Image:               spec.Image,
+		MetricLabelsDefault: spec.MetricLabelsDefault,
+		LimitsRollout:       convertPtr(spec.LimitsRollout, func(r LimitsRollout) limitadorv1alpha1.LimitsRollout { return limitadorv1alpha1.LimitsRollout(r) }),
+	}
+
+	if version, ok := dst.Annotations[VersionAnnotation]; ok {
+		dst.Spec.Version = &version
+		if dst.Spec.Image != nil && *dst.Spec.Image == versionImage(version) && !dst.IsImageDefaulted() {
+			dst.Spec.Image = nil
+		}
+		delete(dst.Annotations, VersionAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	if spec.Pod != nil {
+		pod := spec.Pod
+		dst.Spec.Affinity = pod.Affinity
+		dst.Spec.Tolerations = pod.Tolerations
+		dst.Spec.NodeSelector = pod.NodeSelector
+		dst.Spec.TopologySpreadConstraints = pod.TopologySpreadConstraints
+		dst.Spec.PriorityClassName = pod.PriorityClassName
+		dst.Spec.ServiceAccountName = pod.ServiceAccountName
+		dst.Spec.ImagePullSecrets = pod.ImagePullSecrets
+		dst.Spec.PodSecurityContext = pod.SecurityContext
+		dst.Spec.SecurityContext = pod.ContainerSecurityContext
+		dst.Spec.ResourceRequirements = pod.Resources
+		dst.Spec.Probes = probesToV1alpha1(pod.Probes)
+		if pod.Metadata.Labels != nil || pod.Metadata.Annotations != nil {
+			dst.Spec.PodTemplate = &limitadorv1alpha1.PodTemplate{
+				Metadata: limitadorv1alpha1.PodTemplateMetadata(pod.Metadata),
+			}
+		}
+	}
+
+	for _, limit := range spec.Limits {
+		dst.Spec.Limits = append(dst.Spec.Limits, limitadorv1alpha1.RateLimit{
+			Conditions: limit.Conditions,
+			MaxValue:   limit.MaxValue,
+			Namespace:  limit.Namespace,
+			Seconds:    limit.Seconds,
+			Variables:  limit.Variables,
+			Name:       limit.Name,
+			Mode:       limitadorv1alpha1.LimitMode(limit.Mode),
+		})
+	}
+
+	for _, source := range spec.LimitsFrom {
+		dst.Spec.LimitsFrom = append(dst.Spec.LimitsFrom, limitadorv1alpha1.LimitsSource(source))
+	}
+
+	for _, allowed := range spec.AllowedRateLimitDefinitionNamespaces {
+		dst.Spec.AllowedRateLimitDefinitionNamespaces = append(dst.Spec.AllowedRateLimitDefinitionNamespaces,
+			limitadorv1alpha1.AllowedRateLimitDefinitionNamespace(allowed))
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+// The deprecated spec.version field is converted to the image it stands for, when the image is not set,
+// and kept in the version annotation.
+func (dst *Limitador) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*limitadorv1alpha1.Limitador)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Limitador object but got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Status = statusFromV1alpha1(src.Status.DeepCopy())
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = LimitadorSpec{
+		Service:          convertPtr(spec.Service, func(s limitadorv1alpha1.ServiceSpec) ServiceSpec { return ServiceSpec(s) }),
+		CommonLabels:     spec.CommonLabels,
+		Replicas:         spec.Replicas,
+		Autoscaling:      convertPtr(spec.Autoscaling, func(a limitadorv1alpha1.Autoscaling) Autoscaling { return Autoscaling(a) }),
+		Listener:         listenerFromV1alpha1(spec.Listener),
+		Storage:          storageFromV1alpha1(spec.Storage),
+		RateLimitHeaders: convertPtr(spec.RateLimitHeaders, func(r limitadorv1alpha1.RateLimitHeadersType) RateLimitHeadersType { return RateLimitHeadersType(r) }),
+		Telemetry:        convertPtr(spec.Telemetry, func(t limitadorv1alpha1.Telemetry) Telemetry { return Telemetry(t) }),
+		Tracing:          convertPtr(spec.Tracing, func(t limitadorv1alpha1.Tracing) Tracing { return Tracing(t) }),
+		NetworkPolicy:    convertPtr(spec.NetworkPolicy, func(n limitadorv1alpha1.NetworkPolicySpec) NetworkPolicySpec { return NetworkPolicySpec(n) }),
+		Monitoring:       monitoringFromV1alpha1(spec.Monitoring),
+		PodDisruptionBudget: convertPtr(spec.PodDisruptionBudget, func(p limitadorv1alpha1.PodDisruptionBudgetType) PodDisruptionBudgetType {
+			return PodDisruptionBudgetType(p)
+		}),
+		Verbosity:           convertPtr(spec.Verbosity, func(v limitadorv1alpha1.VerbosityLevel) VerbosityLevel { return VerbosityLevel(v) }),
+		Image:               spec.Image,
+		MetricLabelsDefault: spec.MetricLabelsDefault,
+		LimitsRollout:       convertPtr(spec.LimitsRollout, func(r limitadorv1alpha1.LimitsRollout) LimitsRollout { return LimitsRollout(r) }),
+	}
+
+	if spec.Version != nil {
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[VersionAnnotation] = *spec.Version
+		if spec.Image == nil {
+			dst.Spec.Image = ptr.To(versionImage(*spec.Version))
+		}
+	}
+
+	pod := PodSpec{
+		Affinity:                  spec.Affinity,
+		Tolerations:               spec.Tolerations,
+		NodeSelector:              spec.NodeSelector,
+		TopologySpreadConstraints: spec.TopologySpreadConstraints,
+		PriorityClassName:         spec.PriorityClassName,
+		ServiceAccountName:        spec.ServiceAccountName,
+		ImagePullSecrets:          spec.ImagePullSecrets,
+		SecurityContext:           spec.PodSecurityContext,
+		ContainerSecurityContext:  spec.SecurityContext,
+		Resources:                 spec.ResourceRequirements,
+		Probes:                    probesFromV1alpha1(spec.Probes),
+	}
+	if spec.PodTemplate != nil {
+		pod.Metadata = PodTemplateMetadata(spec.PodTemplate.Metadata)
+	}
+	if !reflect.DeepEqual(pod, PodSpec{}) {
+		dst.Spec.Pod = &pod
+	}
+
+	for _, limit := range spec.Limits {
+		dst.Spec.Limits = append(dst.Spec.Limits, RateLimit{
+			Conditions: limit.Conditions,
+			MaxValue:   limit.MaxValue,
+			Namespace:  limit.Namespace,
+			Seconds:    limit.Seconds,
+			Variables:  limit.Variables,
+			Name:       limit.Name,
+			Mode:       LimitMode(limit.Mode),
+		})
+	}
+
+	for _, source := range spec.LimitsFrom {
+		dst.Spec.LimitsFrom = append(dst.Spec.LimitsFrom, LimitsSource(source))
+	}
+
+	for _, allowed := range spec.AllowedRateLimitDefinitionNamespaces {
+		dst.Spec.AllowedRateLimitDefinitionNamespaces = append(dst.Spec.AllowedRateLimitDefinitionNamespaces,
+			AllowedRateLimitDefinitionNamespace(allowed))
+	}
+
+	return nil
+}
+
+// versionImage is the image the deprecated v1alpha1 spec.version field stands for
+func versionImage(version string) string {
+	return fmt.Sprintf("%s:%s", limitadorRepository, version)
+}
+
+// convertPtr converts the value src points to, a nil pointer stays nil
+func convertPtr[S, D any](src *S, convert func(S) D) *D {
+	if src == nil {
+		return nil
+	}
+
+	return ptr.To(convert(*src))
+}
+
+func listenerToV1alpha1(listener *Listener) *limitadorv1alpha1.Listener {
+	if listener == nil {
+		return nil
+	}
+
+	toV1alpha1 := func(t TransportProtocol) limitadorv1alpha1.TransportProtocol {
+		return limitadorv1alpha1.TransportProtocol(t)
+	}
+	return &limitadorv1alpha1.Listener{
+		HTTP: convertPtr(listener.HTTP, toV1alpha1),
+		GRPC: convertPtr(listener.GRPC, toV1alpha1),
+	}
+}
+
+func listenerFromV1alpha1(listener *limitadorv1alpha1.Listener) *Listener {
+	if listener == nil {
+		return nil
+	}
+
+	fromV1alpha1 := func(t limitadorv1alpha1.TransportProtocol) TransportProtocol { return TransportProtocol(t) }
+	return &Listener{
+		HTTP: convertPtr(listener.HTTP, fromV1alpha1),
+		GRPC: convertPtr(listener.GRPC, fromV1alpha1),
+	}
+}
+
+func monitoringToV1alpha1(monitoring *Monitoring) *limitadorv1alpha1.Monitoring {
+	if monitoring == nil {
+		return nil
+	}
+
+	return &limitadorv1alpha1.Monitoring{
+		ServiceMonitor: convertPtr(monitoring.ServiceMonitor, func(s ServiceMonitorSpec) limitadorv1alpha1.ServiceMonitorSpec {
+			return limitadorv1alpha1.ServiceMonitorSpec{
+				Kind:        convertPtr(s.Kind, func(k MonitorKind) limitadorv1alpha1.MonitorKind { return limitadorv1alpha1.MonitorKind(k) }),
+				Interval:    s.Interval,
+				Labels:      s.Labels,
+				Relabelings: s.Relabelings,
+			}
+		}),
+	}
+}
+
+func monitoringFromV1alpha1(monitoring *limitadorv1alpha1.Monitoring) *Monitoring {
+	if monitoring == nil {
+		return nil
+	}
+
+	return &Monitoring{
+		ServiceMonitor: convertPtr(monitoring.ServiceMonitor, func(s limitadorv1alpha1.ServiceMonitorSpec) ServiceMonitorSpec {
+			return ServiceMonitorSpec{
+				Kind:        convertPtr(s.Kind, func(k limitadorv1alpha1.MonitorKind) MonitorKind { return MonitorKind(k) }),
+				Interval:    s.Interval,
+				Labels:      s.Labels,
+				Relabelings: s.Relabelings,
+			}
+		}),
+	}
+}
+
+func probesToV1alpha1(probes *Probes) *limitadorv1alpha1.Probes {
+	if probes == nil {
+		return nil
+	}
+
+	toV1alpha1 := func(t ProbeTimings) limitadorv1alpha1.ProbeTimings { return limitadorv1alpha1.ProbeTimings(t) }
+	return &limitadorv1alpha1.Probes{
+		Type:      convertPtr(probes.Type, func(t ProbeType) limitadorv1alpha1.ProbeType { return limitadorv1alpha1.ProbeType(t) }),
+		Liveness:  convertPtr(probes.Liveness, toV1alpha1),
+		Readiness: convertPtr(probes.Readiness, toV1alpha1),
+		Startup:   convertPtr(probes.Startup, toV1alpha1),
+	}
+}
+
+func probesFromV1alpha1(probes *limitadorv1alpha1.Probes) *Probes {
+	if probes == nil {
+		return nil
+	}
+
+	fromV1alpha1 := func(t limitadorv1alpha1.ProbeTimings) ProbeTimings { return ProbeTimings(t) }
+	return &Probes{
+		Type:      convertPtr(probes.Type, func(t limitadorv1alpha1.ProbeType) ProbeType { return ProbeType(t) }),
+		Liveness:  convertPtr(probes.Liveness, fromV1alpha1),
+		Readiness: convertPtr(probes.Readiness, fromV1alpha1),
+		Startup:   convertPtr(probes.Startup, fromV1alpha1),
+	}
+}
+
+func storageToV1alpha1(storage *Storage) *limitadorv1alpha1.Storage {
+	if storage == nil {
+		return nil
+	}
+
+	switch storage.Type {
+	case StorageTypeRedis:
+		return &limitadorv1alpha1.Storage{Redis: convertPtr(storage.Redis, func(r Redis) limitadorv1alpha1.Redis {
+			return limitadorv1alpha1.Redis{
+				ConfigSecretRef: r.ConfigSecretRef,
+				TLS:             redisTLSToV1alpha1(r.TLS),
+				Managed: convertPtr(r.Managed, func(m ManagedRedis) limitadorv1alpha1.ManagedRedis {
+					return limitadorv1alpha1.ManagedRedis{
+						Image:        m.Image,
+						Resources:    m.Resources,
+						PVC:          pvcToV1alpha1(m.PVC),
+						RetainPolicy: convertPtr(m.RetainPolicy, retainPolicyToV1alpha1),
+					}
+				}),
+			}
+		})}
+	case StorageTypeRedisCached:
+		return &limitadorv1alpha1.Storage{RedisCached: convertPtr(storage.RedisCached, func(r RedisCached) limitadorv1alpha1.RedisCached {
+			return limitadorv1alpha1.RedisCached{
+				ConfigSecretRef: r.ConfigSecretRef,
+				TLS:             redisTLSToV1alpha1(r.TLS),
+				Options: convertPtr(r.Options, func(o RedisCachedOptions) limitadorv1alpha1.RedisCachedOptions {
+					return limitadorv1alpha1.RedisCachedOptions(o)
+				}),
+			}
+		})}
+	case StorageTypeDisk:
+		return &limitadorv1alpha1.Storage{Disk: convertPtr(storage.Disk, func(d DiskSpec) limitadorv1alpha1.DiskSpec {
+			return limitadorv1alpha1.DiskSpec{
+				PVC: pvcToV1alpha1(d.PVC),
+				Optimize: convertPtr(d.Optimize, func(o DiskOptimizeType) limitadorv1alpha1.DiskOptimizeType {
+					return limitadorv1alpha1.DiskOptimizeType(o)
+				}),
+				RetainPolicy: convertPtr(d.RetainPolicy, retainPolicyToV1alpha1),
+			}
+		})}
+	default:
+		return &limitadorv1alpha1.Storage{Memory: &limitadorv1alpha1.InMemory{}}
+	}
+}
+
+// storageFromV1alpha1 selects the storage the same way the operator does when more than one is set
+func storageFromV1alpha1(storage *limitadorv1alpha1.Storage) *Storage {
+	if storage == nil {
+		return nil
+	}
+
+	switch {
+	case storage.Redis != nil:
+		r := storage.Redis
+		return &Storage{Type: StorageTypeRedis, Redis: &Redis{
+			ConfigSecretRef: r.ConfigSecretRef,
+			TLS:             redisTLSFromV1alpha1(r.TLS),
+			Managed: convertPtr(r.Managed, func(m limitadorv1alpha1.ManagedRedis) ManagedRedis {
+				return ManagedRedis{
+					Image:        m.Image,
+					Resources:    m.Resources,
+					PVC:          pvcFromV1alpha1(m.PVC),
+					RetainPolicy: convertPtr(m.RetainPolicy, retainPolicyFromV1alpha1),
+				}
+			}),
+		}}
+	case storage.RedisCached != nil:
+		r := storage.RedisCached
+		return &Storage{Type: StorageTypeRedisCached, RedisCached: &RedisCached{
+			ConfigSecretRef: r.ConfigSecretRef,
+			TLS:             redisTLSFromV1alpha1(r.TLS),
+			Options: convertPtr(r.Options, func(o limitadorv1alpha1.RedisCachedOptions) RedisCachedOptions {
+				return RedisCachedOptions(o)
+			}),
+		}}
+	case storage.Disk != nil:
+		d := storage.Disk
+		return &Storage{Type: StorageTypeDisk, Disk: &DiskSpec{
+			PVC:          pvcFromV1alpha1(d.PVC),
+			Optimize:     convertPtr(d.Optimize, func(o limitadorv1alpha1.DiskOptimizeType) DiskOptimizeType { return DiskOptimizeType(o) }),
+			RetainPolicy: convertPtr(d.RetainPolicy, retainPolicyFromV1alpha1),
+		}}
+	default:
+		return &Storage{Type: StorageTypeMemory}
+	}
+}
+
+func redisTLSToV1alpha1(tls *RedisTLS) *limitadorv1alpha1.RedisTLS {
+	return convertPtr(tls, func(t RedisTLS) limitadorv1alpha1.RedisTLS {
+		return limitadorv1alpha1.RedisTLS{
+			CABundle: convertPtr(t.CABundle, func(c CABundleSource) limitadorv1alpha1.CABundleSource { return limitadorv1alpha1.CABundleSource(c) }),
+		}
+	})
+}
+
+func redisTLSFromV1alpha1(tls *limitadorv1alpha1.RedisTLS) *RedisTLS {
+	return convertPtr(tls, func(t limitadorv1alpha1.RedisTLS) RedisTLS {
+		return RedisTLS{
+			CABundle: convertPtr(t.CABundle, func(c limitadorv1alpha1.CABundleSource) CABundleSource { return CABundleSource(c) }),
+		}
+	})
+}
+
+func pvcToV1alpha1(pvc *PVCGenericSpec) *limitadorv1alpha1.PVCGenericSpec {
+	return convertPtr(pvc, func(p PVCGenericSpec) limitadorv1alpha1.PVCGenericSpec {
+		return limitadorv1alpha1.PVCGenericSpec{
+			StorageClassName: p.StorageClassName,
+			Resources: convertPtr(p.Resources, func(r PersistentVolumeClaimResources) limitadorv1alpha1.PersistentVolumeClaimResources {
+				return limitadorv1alpha1.PersistentVolumeClaimResources(r)
+			}),
+			VolumeName: p.VolumeName,
+		}
+	})
+}
+
+func pvcFromV1alpha1(pvc *limitadorv1alpha1.PVCGenericSpec) *PVCGenericSpec {
+	return convertPtr(pvc, func(p limitadorv1alpha1.PVCGenericSpec) PVCGenericSpec {
+		return PVCGenericSpec{
+			StorageClassName: p.StorageClassName,
+			Resources: convertPtr(p.Resources, func(r limitadorv1alpha1.PersistentVolumeClaimResources) PersistentVolumeClaimResources {
+				return PersistentVolumeClaimResources(r)
+			}),
+			VolumeName: p.VolumeName,
+		}
+	})
+}
+
+func retainPolicyToV1alpha1(r RetainPolicy) limitadorv1alpha1.RetainPolicy {
+	return limitadorv1alpha1.RetainPolicy(r)
+}
+
+func retainPolicyFromV1alpha1(r limitadorv1alpha1.RetainPolicy) RetainPolicy {
+	return RetainPolicy(r)
+}
+
+func statusToV1alpha1(status *LimitadorStatus) limitadorv1alpha1.LimitadorStatus {
+	dst := limitadorv1alpha1.LimitadorStatus{
+		ObservedGeneration: status.ObservedGeneration,
+		Conditions:         status.Conditions,
+		Service: convertPtr(status.Service, func(s LimitadorService) limitadorv1alpha1.LimitadorService {
+			return limitadorv1alpha1.LimitadorService{Host: s.Host, Ports: limitadorv1alpha1.Ports(s.Ports)}
+		}),
+		LimitsRollout: convertPtr(status.LimitsRollout, func(r LimitsRolloutStatus) limitadorv1alpha1.LimitsRolloutStatus {
+			return limitadorv1alpha1.LimitsRolloutStatus{
+				Phase:         limitadorv1alpha1.LimitsRolloutPhase(r.Phase),
+				ConfigHash:    r.ConfigHash,
+				StartTime:     r.StartTime,
+				BakeStartTime: r.BakeStartTime,
+				Message:       r.Message,
+			}
+		}),
+	}
+
+	dst.Limits = convertPtr(status.Limits, func(l LimitsStatus) limitadorv1alpha1.LimitsStatus {
+		limits := limitadorv1alpha1.LimitsStatus{
+			Total:                       l.Total,
+			Shadow:                      l.Shadow,
+			ConfigHash:                  l.ConfigHash,
+			ConfigMapResourceVersion:    l.ConfigMapResourceVersion,
+			AcknowledgedResourceVersion: l.AcknowledgedResourceVersion,
+			AcknowledgedReplicas:        l.AcknowledgedReplicas,
+			LastUpdateTime:              l.LastUpdateTime,
+		}
+		for _, ns := range l.Namespaces {
+			limits.Namespaces = append(limits.Namespaces, limitadorv1alpha1.NamespaceLimitsStatus(ns))
+		}
+		return limits
+	})
+
+	return dst
+}
+
+func statusFromV1alpha1(status *limitadorv1alpha1.LimitadorStatus) LimitadorStatus {
+	dst := LimitadorStatus{
+		ObservedGeneration: status.ObservedGeneration,
+		Conditions:         status.Conditions,
+		Service: convertPtr(status.Service, func(s limitadorv1alpha1.LimitadorService) LimitadorService {
+			return LimitadorService{Host: s.Host, Ports: Ports(s.Ports)}
+		}),
+		LimitsRollout: convertPtr(status.LimitsRollout, func(r limitadorv1alpha1.LimitsRolloutStatus) LimitsRolloutStatus {
+			return LimitsRolloutStatus{
+				Phase:         LimitsRolloutPhase(r.Phase),
+				ConfigHash:    r.ConfigHash,
+				StartTime:     r.StartTime,
+				BakeStartTime: r.BakeStartTime,
+				Message:       r.Message,
+			}
+		}),
+	}
+
+	dst.Limits = convertPtr(status.Limits, func(l limitadorv1alpha1.LimitsStatus) LimitsStatus {
+		limits := LimitsStatus{
+			Total:                       l.Total,
+			Shadow:                      l.Shadow,
+			ConfigHash:                  l.ConfigHash,
+			ConfigMapResourceVersion:    l.ConfigMapResourceVersion,
+			AcknowledgedResourceVersion: l.AcknowledgedResourceVersion,
+			AcknowledgedReplicas:        l.AcknowledgedReplicas,
+			LastUpdateTime:              l.LastUpdateTime,
+		}
+		for _, ns := range l.Namespaces {
+			limits.Namespaces = append(limits.Namespaces, NamespaceLimitsStatus(ns))
+		}
+		return limits
+	})
+
+	return dst
+}
@@ -0,0 +1,171 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+func TestLimitadorConversion(t *testing.T) {
+	hub := func() *limitadorv1alpha1.Limitador {
+		return &limitadorv1alpha1.Limitador{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-name", Namespace: "some-ns"},
+			Spec: limitadorv1alpha1.LimitadorSpec{
+				Replicas:           ptr.To(2),
+				NodeSelector:       map[string]string{"disktype": "ssd"},
+				ServiceAccountName: ptr.To("limitador"),
+				SecurityContext:    &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)},
+				PodTemplate: &limitadorv1alpha1.PodTemplate{
+					Metadata: limitadorv1alpha1.PodTemplateMetadata{Labels: map[string]string{"team": "platform"}},
+				},
+				Storage: &limitadorv1alpha1.Storage{
+					Redis: &limitadorv1alpha1.Redis{ConfigSecretRef: &corev1.LocalObjectReference{Name: "redis"}},
+				},
+				Limits: []limitadorv1alpha1.RateLimit{
+					{Conditions: []string{"req.method == 'GET'"}, MaxValue: 10, Namespace: "toystore", Seconds: 60, Name: "get"},
+					{MaxValue: 5, Namespace: "toystore", Seconds: 60, Name: "new", Mode: limitadorv1alpha1.LimitModeShadow},
+				},
+				LimitsFrom: []limitadorv1alpha1.LimitsSource{
+					{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "generated-limits"},
+						Key:                  "limits.yaml",
+					}},
+				},
+				LimitsRollout: &limitadorv1alpha1.LimitsRollout{CanaryReplicas: ptr.To(int32(2))},
+				AllowedRateLimitDefinitionNamespaces: []limitadorv1alpha1.AllowedRateLimitDefinitionNamespace{
+					{Namespace: "toystore", LimitNamespaces: []string{"toystore"}},
+				},
+				Image: ptr.To("quay.io/kuadrant/limitador:v2.0.0"),
+			},
+			Status: limitadorv1alpha1.LimitadorStatus{
+				ObservedGeneration: 3,
+				Service: &limitadorv1alpha1.LimitadorService{
+					Host:  "limitador-some-name.some-ns.svc.cluster.local",
+					Ports: limitadorv1alpha1.Ports{HTTP: 8080, GRPC: 8081},
+				},
+				Limits: &limitadorv1alpha1.LimitsStatus{
+					Total: 2, Shadow: 1, Namespaces: []limitadorv1alpha1.NamespaceLimitsStatus{{Namespace: "toystore", Count: 2}},
+				},
+				LimitsRollout: &limitadorv1alpha1.LimitsRolloutStatus{Phase: limitadorv1alpha1.LimitsRolloutPhaseRolledBack, ConfigHash: "some-hash"},
+			},
+		}
+	}
+
+	t.Run("from v1alpha1", func(subT *testing.T) {
+		limObj := &Limitador{}
+		assert.NilError(subT, limObj.ConvertFrom(hub()))
+
+		assert.Equal(subT, limObj.Name, "some-name")
+		assert.Equal(subT, limObj.Status.ObservedGeneration, int64(3))
+		assert.DeepEqual(subT, limObj.Spec.Pod, &PodSpec{
+			Metadata:                 PodTemplateMetadata{Labels: map[string]string{"team": "platform"}},
+			NodeSelector:             map[string]string{"disktype": "ssd"},
+			ServiceAccountName:       ptr.To("limitador"),
+			ContainerSecurityContext: &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)},
+		})
+		assert.Equal(subT, limObj.Spec.Storage.Type, StorageTypeRedis)
+		assert.DeepEqual(subT, limObj.Spec.Limits, []RateLimit{
+			{Conditions: []string{"req.method == 'GET'"}, MaxValue: 10, Namespace: "toystore", Seconds: 60, Name: "get"},
+			{MaxValue: 5, Namespace: "toystore", Seconds: 60, Name: "new", Mode: LimitModeShadow},
+		})
+		assert.DeepEqual(subT, limObj.Status.Limits, &LimitsStatus{
+			Total: 2, Shadow: 1, Namespaces: []NamespaceLimitsStatus{{Namespace: "toystore", Count: 2}},
+		})
+	})
+
+	t.Run("round trip", func(subT *testing.T) {
+		limObj := &Limitador{}
+		assert.NilError(subT, limObj.ConvertFrom(hub()))
+		converted := &limitadorv1alpha1.Limitador{}
+		assert.NilError(subT, limObj.ConvertTo(converted))
+		assert.DeepEqual(subT, converted, hub())
+	})
+
+	t.Run("deprecated version converted to image", func(subT *testing.T) {
+		src := hub()
+		src.Spec.Image = nil
+		src.Spec.Version = ptr.To("v1.6.0")
+		limObj := &Limitador{}
+		assert.NilError(subT, limObj.ConvertFrom(src))
+		assert.DeepEqual(subT, limObj.Spec.Image, ptr.To("quay.io/kuadrant/limitador:v1.6.0"))
+		assert.Equal(subT, limObj.Annotations[VersionAnnotation], "v1.6.0")
+	})
+
+	t.Run("deprecated version round trip", func(subT *testing.T) {
+		for _, image := range []*string{nil, ptr.To("quay.io/kuadrant/limitador:v2.0.0")} {
+			src := hub()
+			src.Spec.Image = image
+			src.Spec.Version = ptr.To("v1.6.0")
+			limObj := &Limitador{}
+			assert.NilError(subT, limObj.ConvertFrom(src))
+			converted := &limitadorv1alpha1.Limitador{}
+			assert.NilError(subT, limObj.ConvertTo(converted))
+			assert.DeepEqual(subT, converted, src)
+		}
+	})
+
+	t.Run("defaulted version image round trip", func(subT *testing.T) {
+		src := hub()
+		src.Spec.Image = ptr.To("quay.io/kuadrant/limitador:v1.6.0")
+		src.Spec.Version = ptr.To("v1.6.0")
+		src.Annotations = map[string]string{limitadorv1alpha1.DefaultedImageAnnotation: "quay.io/kuadrant/limitador:v1.6.0"}
+		limObj := &Limitador{}
+		assert.NilError(subT, limObj.ConvertFrom(src))
+		converted := &limitadorv1alpha1.Limitador{}
+		assert.NilError(subT, limObj.ConvertTo(converted))
+		assert.DeepEqual(subT, converted, src)
+	})
+
+	t.Run("image changed in v1beta1 is kept", func(subT *testing.T) {
+		src := hub()
+		src.Spec.Image = nil
+		src.Spec.Version = ptr.To("v1.6.0")
+		limObj := &Limitador{}
+		assert.NilError(subT, limObj.ConvertFrom(src))
+		limObj.Spec.Image = ptr.To("quay.io/kuadrant/limitador:v2.0.0")
+		converted := &limitadorv1alpha1.Limitador{}
+		assert.NilError(subT, limObj.ConvertTo(converted))
+		assert.DeepEqual(subT, converted.Spec.Image, ptr.To("quay.io/kuadrant/limitador:v2.0.0"))
+	})
+
+	t.Run("in-memory storage", func(subT *testing.T) {
+		limObj := &Limitador{Spec: LimitadorSpec{Storage: &Storage{Type: StorageTypeMemory}}}
+		converted := &limitadorv1alpha1.Limitador{}
+		assert.NilError(subT, limObj.ConvertTo(converted))
+		assert.DeepEqual(subT, converted.Spec.Storage, &limitadorv1alpha1.Storage{Memory: &limitadorv1alpha1.InMemory{}})
+	})
+
+	t.Run("storage union", func(subT *testing.T) {
+		for _, storage := range []*Storage{
+			nil,
+			{Type: StorageTypeMemory},
+			{Type: StorageTypeRedisCached, RedisCached: &RedisCached{Options: &RedisCachedOptions{FlushPeriod: ptr.To(500)}}},
+			{Type: StorageTypeDisk, Disk: &DiskSpec{Optimize: ptr.To(DiskOptimizeTypeDisk), RetainPolicy: ptr.To(RetainPolicyRetain)}},
+			{Type: StorageTypeRedis, Redis: &Redis{Managed: &ManagedRedis{PVC: &PVCGenericSpec{VolumeName: ptr.To("redis")}}}},
+		} {
+			limObj := &Limitador{Spec: LimitadorSpec{Storage: storage}}
+			converted := &limitadorv1alpha1.Limitador{}
+			assert.NilError(subT, limObj.ConvertTo(converted))
+			back := &Limitador{}
+			assert.NilError(subT, back.ConvertFrom(converted))
+			assert.DeepEqual(subT, back.Spec.Storage, storage)
+		}
+	})
+}
+
+func TestLimitadorIsConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NilError(t, limitadorv1alpha1.AddToScheme(scheme))
+	assert.NilError(t, AddToScheme(scheme))
+
+	convertible, err := conversion.IsConvertible(scheme, &limitadorv1alpha1.Limitador{})
+	assert.NilError(t, err)
+	assert.Assert(t, convertible)
+}
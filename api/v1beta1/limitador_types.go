@@ -0,0 +1,761 @@
+/*
+Copyright 2025 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +kubebuilder:validation:Minimum=1
+// +kubebuilder:validation:Maximum=4
+type VerbosityLevel int
+
+// LimitadorSpec defines the desired state of Limitador
+type LimitadorSpec struct {
+	// Pod groups the settings of the limitador pods
+	// +optional
+	Pod *PodSpec `json:"pod,omitempty"`
+
+	// Service customizes the Service exposing the limitador pods
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
+	// CommonLabels are added to every object owned by the Limitador CR, including the limitador pods.
+	// They do not override the labels set by the operator.
+	// +kubebuilder:validation:XValidation:rule="!('app' in self) && !('limitador-resource' in self)",message="labels app and limitador-resource are reserved"
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// +optional
+	Replicas *int `json:"replicas,omitempty"`
+
+	// Autoscaling makes the operator manage a HorizontalPodAutoscaler scaling the limitador deployment.
+	// Mutually exclusive with replicas.
+	// +optional
+	Autoscaling *Autoscaling `json:"autoscaling,omitempty"`
+
+	// +optional
+	Listener *Listener `json:"listener,omitempty"`
+
+	// Storage of the counters. In memory when not set.
+	// +optional
+	Storage *Storage `json:"storage,omitempty"`
+
+	// +optional
+	RateLimitHeaders *RateLimitHeadersType `json:"rateLimitHeaders,omitempty"`
+
+	// +optional
+	Telemetry *Telemetry `json:"telemetry,omitempty"`
+
+	// +optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+
+	// NetworkPolicy makes the operator manage a NetworkPolicy restricting the traffic of the limitador pods
+	// +optional
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// Monitoring configures the Prometheus monitoring of the limitador pods
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// +optional
+	Limits []RateLimit `json:"limits,omitempty"`
+
+	// LimitsFrom references ConfigMap and Secret keys holding limits in the limitador format.
+	// Their limits are loaded after the inline limits, in the order of the references.
+	// +optional
+	LimitsFrom []LimitsSource `json:"limitsFrom,omitempty"`
+
+	// LimitsRollout stages the rollout of the limits changes. Canary pods load the new limits first,
+	// the other pods load them once the canary pods stayed ready for the bake time.
+	// Not supported with disk storage.
+	// +optional
+	LimitsRollout *LimitsRollout `json:"limitsRollout,omitempty"`
+
+	// AllowedRateLimitDefinitionNamespaces lists the namespaces, besides the namespace of the Limitador CR,
+	// whose RateLimitDefinition objects may add limits to this Limitador instance.
+	// +listType=map
+	// +listMapKey=namespace
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	AllowedRateLimitDefinitionNamespaces []AllowedRateLimitDefinitionNamespace `json:"allowedRateLimitDefinitionNamespaces,omitempty"`
+
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetType `json:"pdb,omitempty"`
+
+	// Sets the level of verbosity
+	// +optional
+	Verbosity *VerbosityLevel `json:"verbosity,omitempty"`
+
+	// Image of the limitador container
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// MetricLabelsDefault specifies the default metric-labels selector used by Limitador
+	// when automatically discovering custom metrics
+	// +optional
+	MetricLabelsDefault *string `json:"metricLabelsDefault,omitempty"`
+}
+
+// PodSpec holds the settings of the limitador pods
+type PodSpec struct {
+	// Metadata holds the metadata added to the limitador pods
+	// +optional
+	Metadata PodTemplateMetadata `json:"metadata,omitempty"`
+
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// TopologySpreadConstraints of the limitador pods.
+	// Constraints without labelSelector select the pods of this Limitador instance.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// ServiceAccountName is the name of the ServiceAccount used to run the limitador pods.
+	// The ServiceAccount must exist in the namespace of the Limitador CR.
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// SecurityContext holds the pod-level security attributes of the limitador pods
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext holds the security attributes of the limitador container
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// Resources of the limitador container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Probes overrides the health probes of the limitador container
+	// +optional
+	Probes *Probes `json:"probes,omitempty"`
+}
+
+type PodTemplateMetadata struct {
+	// Labels added to the limitador pods. They do not override the labels set by the operator.
+	// +kubebuilder:validation:XValidation:rule="!('app' in self) && !('limitador-resource' in self)",message="labels app and limitador-resource are reserved"
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the limitador pods. They do not override the annotations set by the operator.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=HTTP;GRPC
+type ProbeType string
+
+const (
+	// ProbeTypeHTTP probes GET /status on the HTTP listener
+	ProbeTypeHTTP ProbeType = "HTTP"
+	// ProbeTypeGRPC probes the gRPC health service on the RLS listener
+	ProbeTypeGRPC ProbeType = "GRPC"
+)
+
+type Probes struct {
+	// Type of the probes. HTTP probes GET /status on the HTTP listener, GRPC probes the
+	// gRPC health service on the RLS listener. Defaults to HTTP.
+	// +optional
+	Type *ProbeType `json:"type,omitempty"`
+
+	// Liveness overrides the timings of the liveness probe
+	// +optional
+	Liveness *ProbeTimings `json:"liveness,omitempty"`
+
+	// Readiness overrides the timings of the readiness probe
+	// +optional
+	Readiness *ProbeTimings `json:"readiness,omitempty"`
+
+	// Startup adds a startup probe, holding off the liveness and readiness probes until it succeeds.
+	// Useful for instances with a long warm-up.
+	// +optional
+	Startup *ProbeTimings `json:"startup,omitempty"`
+}
+
+// ProbeTimings overrides the timings of a probe. Unset fields keep the operator defaults.
+type ProbeTimings struct {
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.externalTrafficPolicy) || (has(self.type) && self.type != 'ClusterIP')",message="externalTrafficPolicy requires a NodePort or LoadBalancer service"
+type ServiceSpec struct {
+	// Type of the Service. When not set, the Service is a headless ClusterIP Service.
+	// Switching from or to a headless Service recreates the Service.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type *corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations added to the Service, e.g. cloud provider load balancer settings
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// TrafficDistribution expresses a preference for how traffic is routed to the limitador pods,
+	// e.g. PreferClose for topology aware routing
+	// +optional
+	TrafficDistribution *string `json:"trafficDistribution,omitempty"`
+
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy *corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type Autoscaling struct {
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization, over all the pods,
+	// represented as a percentage of the requested CPU
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the target average memory utilization, over all the pods,
+	// represented as a percentage of the requested memory
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics are additional metrics, e.g. custom or external metrics, used to compute the desired replica count.
+	// When no metric nor target is set, the HorizontalPodAutoscaler defaults to 80% average CPU utilization.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+type Listener struct {
+	// +optional
+	HTTP *TransportProtocol `json:"http,omitempty"`
+	// +optional
+	GRPC *TransportProtocol `json:"grpc,omitempty"`
+}
+
+type TransportProtocol struct {
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// StorageType is the type of the storage of the counters
+// +kubebuilder:validation:Enum=Memory;Redis;RedisCached;Disk
+type StorageType string
+
+const (
+	StorageTypeMemory      StorageType = "Memory"
+	StorageTypeRedis       StorageType = "Redis"
+	StorageTypeRedisCached StorageType = "RedisCached"
+	StorageTypeDisk        StorageType = "Disk"
+)
+
+// Storage holds the options of the storage type selected by the type field
+// +union
+// +kubebuilder:validation:XValidation:rule="has(self.redis) == (self.type == 'Redis')",message="redis must be set if and only if type is Redis"
+// +kubebuilder:validation:XValidation:rule="has(self.redisCached) == (self.type == 'RedisCached')",message="redisCached must be set if and only if type is RedisCached"
+// +kubebuilder:validation:XValidation:rule="has(self.disk) == (self.type == 'Disk')",message="disk must be set if and only if type is Disk"
+type Storage struct {
+	// +unionDiscriminator
+	Type StorageType `json:"type"`
+
+	// +optional
+	Redis *Redis `json:"redis,omitempty"`
+
+	// +optional
+	RedisCached *RedisCached `json:"redisCached,omitempty"`
+
+	// +optional
+	Disk *DiskSpec `json:"disk,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.managed) || !(has(self.configSecretRef) || has(self.tls))",message="managed is mutually exclusive with configSecretRef and tls"
+type Redis struct {
+	// ConfigSecretRef refers to the secret holding the URL for Redis.
+	// +optional
+	ConfigSecretRef *corev1.LocalObjectReference `json:"configSecretRef,omitempty"`
+
+	// +optional
+	TLS *RedisTLS `json:"tls,omitempty"`
+
+	// Managed makes the operator deploy a single Redis instance for the Limitador CR,
+	// along with the secret holding its URL. Intended for development and small clusters.
+	// +optional
+	Managed *ManagedRedis `json:"managed,omitempty"`
+}
+
+// ManagedRedis contains the options of the Redis instance deployed by the operator
+type ManagedRedis struct {
+	// Image overrides the Redis image
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PVC configures the persistent volume claim holding the Redis data
+	// +optional
+	PVC *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+
+	// RetainPolicy tells whether the persistent volume claim holding the Redis data
+	// is kept when the Limitador object is deleted
+	// +optional
+	RetainPolicy *RetainPolicy `json:"retainPolicy,omitempty"`
+}
+
+// RedisTLS contains the options to connect to Redis over TLS, i.e. using a rediss:// URL
+type RedisTLS struct {
+	// CABundle refers to the CA certificates used to verify the Redis server certificate,
+	// instead of the system trust store.
+	// +optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+}
+
+// CABundleSource refers to a PEM encoded CA bundle stored either in a ConfigMap or in a Secret
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type CABundleSource struct {
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+type RedisCached struct {
+	// ConfigSecretRef refers to the secret holding the URL for Redis.
+	// +optional
+	ConfigSecretRef *corev1.LocalObjectReference `json:"configSecretRef,omitempty"`
+
+	// +optional
+	TLS *RedisTLS `json:"tls,omitempty"`
+
+	// +optional
+	Options *RedisCachedOptions `json:"options,omitempty"`
+}
+
+type RedisCachedOptions struct {
+	// FlushPeriod for counters in milliseconds [default: 1000]
+	// +optional
+	FlushPeriod *int `json:"flush-period,omitempty"`
+
+	// MaxCached refers to the maximum amount of counters cached [default: 10000]
+	// +optional
+	MaxCached *int `json:"max-cached,omitempty"`
+
+	// ResponseTimeout defines the timeout for Redis commands in milliseconds [default: 350]
+	// +optional
+	ResponseTimeout *int `json:"response-timeout,omitempty"`
+
+	// BatchSize defines the size of entries to flush in as single flush [default: 100]
+	// +optional
+	BatchSize *int `json:"batch-size,omitempty"`
+}
+
+// DiskOptimizeType defines the valid options for "optimize" option of the disk persistence type
+// +kubebuilder:validation:Enum=throughput;disk
+type DiskOptimizeType string
+
+const (
+	DiskOptimizeTypeThroughput DiskOptimizeType = "throughput"
+	DiskOptimizeTypeDisk       DiskOptimizeType = "disk"
+)
+
+// RetainPolicy defines what happens to the persistent volume claim of the storage
+// when the Limitador object is deleted
+// +kubebuilder:validation:Enum=Retain;Delete
+type RetainPolicy string
+
+const (
+	// RetainPolicyRetain keeps the persistent volume claim, releasing it from the Limitador object
+	RetainPolicyRetain RetainPolicy = "Retain"
+	// RetainPolicyDelete deletes the persistent volume claim along with the Limitador object
+	RetainPolicyDelete RetainPolicy = "Delete"
+)
+
+type DiskSpec struct {
+	// +optional
+	PVC *PVCGenericSpec `json:"persistentVolumeClaim,omitempty"`
+
+	// +optional
+	Optimize *DiskOptimizeType `json:"optimize,omitempty"`
+
+	// RetainPolicy tells whether the persistent volume claim holding the counters
+	// is kept when the Limitador object is deleted
+	// +optional
+	RetainPolicy *RetainPolicy `json:"retainPolicy,omitempty"`
+}
+
+type PVCGenericSpec struct {
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Resources represents the minimum resources the volume should have.
+	// Ignored when VolumeName field is set
+	// +optional
+	Resources *PersistentVolumeClaimResources `json:"resources,omitempty"`
+	// VolumeName is the binding reference to the PersistentVolume backing this claim.
+	// +optional
+	VolumeName *string `json:"volumeName,omitempty"`
+}
+
+// PersistentVolumeClaimResources defines the resources configuration
+// of the backup data destination PersistentVolumeClaim
+type PersistentVolumeClaimResources struct {
+	// Storage Resource requests to be used on the PersistentVolumeClaim.
+	// To learn more about resource requests see:
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	Requests resource.Quantity `json:"requests"`
+}
+
+// RateLimitHeadersType defines the valid options for the --rate-limit-headers arg
+// +kubebuilder:validation:Enum=NONE;DRAFT_VERSION_03
+type RateLimitHeadersType string
+
+const (
+	RateLimitHeadersTypeNONE    RateLimitHeadersType = "NONE"
+	RateLimitHeadersTypeDraft03 RateLimitHeadersType = "DRAFT_VERSION_03"
+)
+
+// Telemetry defines the level of metrics Limitador will expose to the user
+// +kubebuilder:validation:Enum=basic;exhaustive
+type Telemetry string
+
+const (
+	TelemetryBasic      Telemetry = "basic"
+	TelemetryExhaustive Telemetry = "exhaustive"
+)
+
+type Tracing struct {
+	Endpoint string `json:"endpoint"`
+}
+
+type NetworkPolicySpec struct {
+	// GRPC lists the sources allowed to reach the gRPC (RLS) listener.
+	// When not set, the gRPC listener accepts traffic from any source.
+	// +optional
+	GRPC []networkingv1.NetworkPolicyPeer `json:"grpc,omitempty"`
+
+	// HTTP lists the sources allowed to reach the HTTP listener.
+	// When not set, the HTTP listener accepts traffic from any source.
+	// The operator pods are always allowed, as they query the limits loaded by the limitador pods.
+	// +optional
+	HTTP []networkingv1.NetworkPolicyPeer `json:"http,omitempty"`
+
+	// Egress restricts the egress traffic of the limitador pods to DNS resolution,
+	// the Redis storage and the tracing endpoint
+	// +optional
+	Egress bool `json:"egress,omitempty"`
+}
+
+type Monitoring struct {
+	// ServiceMonitor makes the operator manage a Prometheus Operator monitor scraping
+	// the limitador metrics endpoint. Requires the monitoring.coreos.com CRDs.
+	// +optional
+	ServiceMonitor *ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+type MonitorKind string
+
+const (
+	MonitorKindServiceMonitor MonitorKind = "ServiceMonitor"
+	MonitorKindPodMonitor     MonitorKind = "PodMonitor"
+)
+
+type ServiceMonitorSpec struct {
+	// Kind of the monitor, scraping the pods through the limitador Service or directly.
+	// Defaults to ServiceMonitor.
+	// +optional
+	Kind *MonitorKind `json:"kind,omitempty"`
+
+	// Interval at which metrics are scraped. Defaults to the scrape interval of Prometheus.
+	// +optional
+	Interval *monitoringv1.Duration `json:"interval,omitempty"`
+
+	// Labels added to the monitor, e.g. to match the monitor selector of the Prometheus instance.
+	// They do not override the labels set by the operator.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Relabelings applied to the scraped targets before ingestion
+	// +optional
+	Relabelings []monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+}
+
+// LimitsSource references a key of a ConfigMap or a Secret of the Limitador namespace
+// holding a list of limits in the limitador format
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef and secretKeyRef must be set"
+type LimitsSource struct {
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// LimitsRollout configures the staged rollout of the limits changes
+type LimitsRollout struct {
+	// CanaryReplicas is the number of canary pods loading the new limits first.
+	// They run next to the limitador pods for the time of the rollout. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CanaryReplicas *int32 `json:"canaryReplicas,omitempty"`
+
+	// BakeTime is how long the canary pods must stay ready before the other pods load the new limits.
+	// The canary pods have the same time to get ready. Defaults to 5m.
+	// +optional
+	BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+}
+
+// AllowedRateLimitDefinitionNamespace grants the RateLimitDefinition objects of a namespace
+// to add limits of some limit namespaces
+type AllowedRateLimitDefinitionNamespace struct {
+	// Namespace holding the RateLimitDefinition objects
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// LimitNamespaces lists the values the namespace field of the limits may take
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	LimitNamespaces []string `json:"limitNamespaces"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!(has(self.maxUnavailable) && has(self.minAvailable))",message="pdb spec invalid, maxUnavailable and minAvailable are mutually exclusive"
+type PodDisruptionBudgetType struct {
+	// An eviction is allowed if at most "maxUnavailable" limitador pods
+	// are unavailable after the eviction, i.e. even in absence of
+	// the evicted pod. For example, one can prevent all voluntary evictions
+	// by specifying 0. This is a mutually exclusive setting with "minAvailable".
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// An eviction is allowed if at least "minAvailable" limitador pods will
+	// still be available after the eviction, i.e. even in the absence of
+	// the evicted pod.  So for example you can prevent all voluntary
+	// evictions by specifying "100%".
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// LimitMode tells whether limitador rejects the requests over the limit
+// +kubebuilder:validation:Enum=enforce;shadow
+type LimitMode string
+
+const (
+	// LimitModeEnforce limits reject the requests over the limit
+	LimitModeEnforce LimitMode = "enforce"
+	// LimitModeShadow limits count the requests but never reject them
+	LimitModeShadow LimitMode = "shadow"
+)
+
+// RateLimit defines the desired Limitador limit
+type RateLimit struct {
+	Conditions []string `json:"conditions"`
+	MaxValue   int      `json:"maxValue"`
+	Namespace  string   `json:"namespace"`
+	Seconds    int      `json:"seconds"`
+	Variables  []string `json:"variables"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Mode of the limit, enforce when not set.
+	// Shadow limits are loaded by limitador with a maximum value never reached.
+	// The operator reports the hits of the shadow limits in its metrics.
+	// +optional
+	Mode LimitMode `json:"mode,omitempty"`
+}
+
+// LimitadorStatus defines the observed state of Limitador
+type LimitadorStatus struct {
+	// ObservedGeneration reflects the generation of the most recently observed spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the observations of the current state of the Limitador instance.
+	// Known .status.conditions.type are: "Ready", "LimitsValid", "LimitsSynced", "Monitoring"
+	// and "StorageConfigDrift"
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Service provides information about the service exposing limitador API
+	// +optional
+	Service *LimitadorService `json:"service,omitempty"`
+
+	// Limits summarizes the limits loaded in the limits ConfigMap and their rollout to the pods
+	// +optional
+	Limits *LimitsStatus `json:"limits,omitempty"`
+
+	// LimitsRollout reports the staged rollout of the limits in progress, or rolled back
+	// +optional
+	LimitsRollout *LimitsRolloutStatus `json:"limitsRollout,omitempty"`
+}
+
+type LimitadorService struct {
+	// Host is the cluster DNS name of the Service or, for LoadBalancer Services,
+	// the ingress address of the load balancer once provisioned
+	Host  string `json:"host,omitempty"`
+	Ports Ports  `json:"ports,omitempty"`
+}
+
+type Ports struct {
+	HTTP int32 `json:"http,omitempty"`
+	GRPC int32 `json:"grpc,omitempty"`
+}
+
+type LimitsStatus struct {
+	// Total number of limits in the limits ConfigMap, including the ones from RateLimitDefinition objects
+	Total int32 `json:"total"`
+
+	// Shadow is the number of limits in shadow mode, counted but never enforced
+	// +optional
+	Shadow int32 `json:"shadow,omitempty"`
+
+	// Namespaces reports the number of limits per limit namespace
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Namespaces []NamespaceLimitsStatus `json:"namespaces,omitempty"`
+
+	// ConfigHash is the SHA-256 hash of the rendered limits config file
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// ConfigMapResourceVersion is the resource version of the limits ConfigMap
+	// +optional
+	ConfigMapResourceVersion string `json:"configMapResourceVersion,omitempty"`
+
+	// AcknowledgedResourceVersion is the resource version of the limits ConfigMap acknowledged by every pod.
+	// Empty while the pods have not acknowledged the same version.
+	// +optional
+	AcknowledgedResourceVersion string `json:"acknowledgedResourceVersion,omitempty"`
+
+	// AcknowledgedReplicas is the number of pods that acknowledged the current limits ConfigMap resource version
+	// +optional
+	AcknowledgedReplicas int32 `json:"acknowledgedReplicas,omitempty"`
+
+	// LastUpdateTime is the last time the rendered limits config changed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type NamespaceLimitsStatus struct {
+	Namespace string `json:"namespace"`
+	Count     int32  `json:"count"`
+}
+
+// LimitsRolloutPhase is the phase of a staged rollout of the limits
+type LimitsRolloutPhase string
+
+const (
+	// LimitsRolloutPhaseCanary means the canary pods are getting ready with the new limits
+	LimitsRolloutPhaseCanary LimitsRolloutPhase = "Canary"
+	// LimitsRolloutPhaseBaking means the canary pods are ready and baking the new limits
+	LimitsRolloutPhaseBaking LimitsRolloutPhase = "Baking"
+	// LimitsRolloutPhaseRolledBack means the canary pods were not ready, the new limits were not loaded by the other pods
+	LimitsRolloutPhaseRolledBack LimitsRolloutPhase = "RolledBack"
+)
+
+type LimitsRolloutStatus struct {
+	Phase LimitsRolloutPhase `json:"phase"`
+
+	// ConfigHash is the SHA-256 hash of the limits config rolled out
+	ConfigHash string `json:"configHash"`
+
+	// StartTime is the time the canary pods got the limits config
+	StartTime metav1.Time `json:"startTime"`
+
+	// BakeStartTime is the time every canary pod was ready
+	// +optional
+	BakeStartTime *metav1.Time `json:"bakeStartTime,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Limitador Ready",priority=2
+//+kubebuilder:printcolumn:name="Limits",type=integer,JSONPath=`.status.limits.total`,description="Number of limits"
+//+kubebuilder:printcolumn:name="Acknowledged",type=integer,JSONPath=`.status.limits.acknowledgedReplicas`,description="Pods running the current limits"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Limitador is the Schema for the limitadors API
+type Limitador struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="!has(self.storage) || self.storage.type != 'Disk' || !has(self.replicas) || self.replicas < 2",message="disk storage does not allow multiple replicas"
+	// +kubebuilder:validation:XValidation:rule="!has(self.storage) || self.storage.type != 'Disk' || !has(self.autoscaling)",message="disk storage does not allow autoscaling"
+	// +kubebuilder:validation:XValidation:rule="!(has(self.replicas) && has(self.autoscaling))",message="replicas and autoscaling are mutually exclusive"
+	// +kubebuilder:validation:XValidation:rule="!has(self.storage) || self.storage.type != 'Disk' || !has(self.limitsRollout)",message="disk storage does not allow limits rollout"
+	Spec   LimitadorSpec   `json:"spec,omitempty"`
+	Status LimitadorStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LimitadorList contains a list of Limitador
+type LimitadorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Limitador `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Limitador{}, &LimitadorList{})
+}
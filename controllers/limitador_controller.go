@@ -19,23 +19,40 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"slices"
 
 	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
 	"github.com/kuadrant/limitador-operator/pkg/limitador"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient"
 	"github.com/kuadrant/limitador-operator/pkg/observability"
 	"github.com/kuadrant/limitador-operator/pkg/reconcilers"
 )
@@ -43,13 +60,22 @@ import (
 // LimitadorReconciler reconciles a Limitador object
 type LimitadorReconciler struct {
 	*reconcilers.BaseReconciler
+
+	// LimitsClient queries the limitador pods to check the limits are loaded.
+	// The LimitsSynced condition is not reported when nil.
+	LimitsClient limitadorclient.Client
 }
 
 //+kubebuilder:rbac:groups=limitador.kuadrant.io,resources=limitadors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=limitador.kuadrant.io,resources=limitadors/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=limitador.kuadrant.io,resources=limitadors/finalizers,verbs=update
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services;configmaps;secrets;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch;update;patch
 
@@ -76,6 +102,7 @@ func (r *LimitadorReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 	if err := r.Client().Get(ctx, req.NamespacedName, limitadorObj); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("no object found")
+			observability.DeleteLimitadorMetrics(req.NamespacedName)
 			observability.RecordReconcileResult(span, ctrl.Result{}, nil)
 			return ctrl.Result{}, nil
 		}
@@ -100,18 +127,7 @@ func (r *LimitadorReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 	}
 
 	// Add Limitador-specific attributes to span
-	storageType := "memory" // default
-	if limitadorObj.Spec.Storage != nil {
-		if limitadorObj.Spec.Storage.RedisCached != nil {
-			storageType = "redis-cached"
-		} else if limitadorObj.Spec.Storage.Redis != nil {
-			storageType = "redis"
-		} else if limitadorObj.Spec.Storage.Disk != nil {
-			storageType = "disk"
-		}
-	}
-
-	observability.AddLimitadorAttributes(span, limitadorObj.Namespace, limitadorObj.Name, limitadorObj.GetReplicas(), storageType)
+	observability.AddLimitadorAttributes(span, limitadorObj.Namespace, limitadorObj.Name, limitadorObj.GetReplicas(), storageType(limitadorObj))
 
 	if logger.V(1).Enabled() {
 		jsonData, err := json.MarshalIndent(limitadorObj, "", "  ")
@@ -124,13 +140,31 @@ func (r *LimitadorReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 
 	if limitadorObj.GetDeletionTimestamp() != nil {
 		logger.Info("marked to be deleted")
+		observability.DeleteLimitadorMetrics(req.NamespacedName)
+		if err := r.finalizeLimitador(ctx, limitadorObj); err != nil {
+			observability.RecordError(span, err, "failed to finalize Limitador object")
+			return ctrl.Result{}, err
+		}
 		observability.RecordReconcileResult(span, ctrl.Result{}, nil)
 		return ctrl.Result{}, nil
 	}
 
-	specResult, specErr := r.reconcileSpec(ctx, limitadorObj)
+	if err := r.ensureFinalizer(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to add finalizer")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileDefaultedImage(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to update defaulted image")
+		return ctrl.Result{}, err
+	}
+
+	specResult, sourcesLimits, specErr := r.reconcileSpec(ctx, limitadorObj)
+	if specErr == nil {
+		specErr = r.removeFinalizer(ctx, limitadorObj)
+	}
 
-	statusResult, statusErr := r.reconcileStatus(ctx, limitadorObj, specErr)
+	statusResult, statusErr := r.reconcileStatus(ctx, limitadorObj, sourcesLimits, specErr)
 
 	if specErr != nil {
 		observability.RecordError(span, specErr, "spec reconciliation failed")
@@ -159,42 +193,95 @@ func (r *LimitadorReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 	return ctrl.Result{}, nil
 }
 
-func (r *LimitadorReconciler) reconcileSpec(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (ctrl.Result, error) {
+// reconcileDefaultedImage writes the image resolved again into spec.image when it was written by the defaulting webhook,
+// so the Limitador object shows the image deployed once the operator is upgraded
+func (r *LimitadorReconciler) reconcileDefaultedImage(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	if !limitadorObj.IsImageDefaulted() {
+		return nil
+	}
+
+	image := limitador.Image(limitadorObj)
+	if image == *limitadorObj.Spec.Image {
+		return nil
+	}
+
+	limitadorObj.Spec.Image = &image
+	limitadorObj.Annotations[limitadorv1alpha1.DefaultedImageAnnotation] = image
+	return r.UpdateResource(ctx, limitadorObj)
+}
+
+// reconcileSpec returns the limits of the spec.limitsFrom sources once loaded, reported by the status
+func (r *LimitadorReconciler) reconcileSpec(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (ctrl.Result, *limitador.SourcesLimits, error) {
 	ctx, span := r.Tracer().StartReconcileSpecSpan(ctx)
 	defer span.End()
 
 	if err := r.reconcileService(ctx, limitadorObj); err != nil {
 		observability.RecordError(span, err, "failed to reconcile service")
-		return ctrl.Result{}, err
+		observability.RecordReconcileError(observability.PhaseService)
+		return ctrl.Result{}, nil, err
 	}
 
 	if err := r.reconcilePVC(ctx, limitadorObj); err != nil {
 		observability.RecordError(span, err, "failed to reconcile PVC")
-		return ctrl.Result{}, err
+		observability.RecordReconcileError(observability.PhasePVC)
+		return ctrl.Result{}, nil, err
+	}
+
+	if err := r.reconcileManagedRedis(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to reconcile managed redis")
+		observability.RecordReconcileError(observability.PhaseManagedRedis)
+		return ctrl.Result{}, nil, err
 	}
 
 	if err := r.reconcileDeployment(ctx, limitadorObj); err != nil {
 		observability.RecordError(span, err, "failed to reconcile deployment")
-		return ctrl.Result{}, err
+		observability.RecordReconcileError(observability.PhaseDeployment)
+		return ctrl.Result{}, nil, err
 	}
 
-	if err := r.reconcileLimitsConfigMap(ctx, limitadorObj); err != nil {
+	limitsResult, sourcesLimits, err := r.reconcileLimitsConfigMap(ctx, limitadorObj)
+	if err != nil {
 		observability.RecordError(span, err, "failed to reconcile limits ConfigMap")
-		return ctrl.Result{}, err
+		observability.RecordReconcileError(observability.PhaseConfigMap)
+		return ctrl.Result{}, sourcesLimits, err
 	}
 
 	if err := r.reconcilePdb(ctx, limitadorObj); err != nil {
 		observability.RecordError(span, err, "failed to reconcile PodDisruptionBudget")
-		return ctrl.Result{}, err
+		observability.RecordReconcileError(observability.PhasePDB)
+		return ctrl.Result{}, sourcesLimits, err
+	}
+
+	if err := r.reconcileHPA(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to reconcile HorizontalPodAutoscaler")
+		observability.RecordReconcileError(observability.PhaseHPA)
+		return ctrl.Result{}, sourcesLimits, err
+	}
+
+	if err := r.reconcileNetworkPolicy(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to reconcile NetworkPolicy")
+		observability.RecordReconcileError(observability.PhaseNetworkPolicy)
+		return ctrl.Result{}, sourcesLimits, err
+	}
+
+	if err := r.reconcileMonitoring(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to reconcile monitoring")
+		observability.RecordReconcileError(observability.PhaseMonitoring)
+		return ctrl.Result{}, sourcesLimits, err
 	}
 
 	result, err := r.reconcilePodLimitsHashAnnotation(ctx, limitadorObj)
 	if err != nil {
 		observability.RecordError(span, err, "failed to reconcile pod annotations")
+		observability.RecordReconcileError(observability.PhasePodAnnotations)
 	} else {
 		observability.RecordSpecCompleted(span)
 	}
-	return result, err
+	// The limits rollout checks the canary pods again after a delay
+	if limitsResult.RequeueAfter > 0 && (result.RequeueAfter == 0 || limitsResult.RequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = limitsResult.RequeueAfter
+	}
+	return result, sourcesLimits, err
 }
 
 func (r *LimitadorReconciler) reconcilePodLimitsHashAnnotation(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (ctrl.Result, error) {
@@ -275,6 +362,66 @@ func (r *LimitadorReconciler) reconcilePdb(ctx context.Context, limitadorObj *li
 	return nil
 }
 
+func (r *LimitadorReconciler) reconcileHPA(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	ctx, span := r.Tracer().StartResourceSpan(ctx, "HorizontalPodAutoscaler", limitadorObj.Namespace, limitador.HorizontalPodAutoscalerName(limitadorObj))
+	defer span.End()
+
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	hpa := limitador.HorizontalPodAutoscaler(limitadorObj)
+	if err := r.SetOwnerReference(limitadorObj, hpa); err != nil {
+		observability.RecordError(span, err, "failed to set owner reference")
+		return err
+	}
+
+	err = r.ReconcileResource(ctx, hpa)
+	logger.V(1).Info("reconcile hpa", "error", err)
+	if err != nil {
+		observability.RecordError(span, err, "failed to reconcile HorizontalPodAutoscaler")
+		return err
+	}
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (r *LimitadorReconciler) reconcileNetworkPolicy(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	ctx, span := r.Tracer().StartResourceSpan(ctx, "NetworkPolicy", limitadorObj.Namespace, limitador.NetworkPolicyName(limitadorObj))
+	defer span.End()
+
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var egressRules []networkingv1.NetworkPolicyEgressRule
+	if limitadorObj.Spec.NetworkPolicy != nil && limitadorObj.Spec.NetworkPolicy.Egress {
+		// The storage secret and the Redis services are not in the label filtered cache
+		egressRules, err = limitador.NetworkPolicyEgressRules(ctx, r.APIClientReader(), limitadorObj)
+		if err != nil {
+			observability.RecordError(span, err, "failed to build egress rules")
+			return err
+		}
+	}
+
+	networkPolicy := limitador.NetworkPolicy(limitadorObj, egressRules)
+	if err := r.SetOwnerReference(limitadorObj, networkPolicy); err != nil {
+		observability.RecordError(span, err, "failed to set owner reference")
+		return err
+	}
+
+	err = r.ReconcileResource(ctx, networkPolicy)
+	logger.V(1).Info("reconcile network policy", "error", err)
+	if err != nil {
+		observability.RecordError(span, err, "failed to reconcile NetworkPolicy")
+		return err
+	}
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
 func (r *LimitadorReconciler) reconcileDeployment(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
 	ctx, span := r.Tracer().StartResourceSpan(ctx, "Deployment", limitadorObj.Namespace, limitador.DeploymentName(limitadorObj))
 	defer span.End()
@@ -322,6 +469,31 @@ func (r *LimitadorReconciler) reconcileService(ctx context.Context, limitadorObj
 		return err
 	}
 
+	// The cluster IP of a Service is immutable. Switching from or to a headless Service
+	// requires to recreate it.
+	existingService := &corev1.Service{}
+	err = r.GetResource(ctx, client.ObjectKeyFromObject(limitadorService), existingService)
+	if err != nil && !apierrors.IsNotFound(err) {
+		observability.RecordError(span, err, "failed to get service")
+		return err
+	}
+	if err == nil {
+		if existingService.GetDeletionTimestamp() != nil {
+			// the deletion event triggers a new reconciliation
+			logger.V(1).Info("reconcile service", "status", "service being deleted")
+			return nil
+		}
+
+		if isHeadlessService(existingService) != isHeadlessService(limitadorService) {
+			if err := r.DeleteResource(ctx, existingService); err != nil {
+				observability.RecordError(span, err, "failed to delete service")
+				return err
+			}
+			logger.V(1).Info("reconcile service", "status", "service recreated")
+			return nil
+		}
+	}
+
 	err = r.ReconcileService(ctx, limitadorService)
 	logger.V(1).Info("reconcile service", "error", err)
 	if err != nil {
@@ -333,6 +505,10 @@ func (r *LimitadorReconciler) reconcileService(ctx context.Context, limitadorObj
 	return nil
 }
 
+func isHeadlessService(service *corev1.Service) bool {
+	return service.Spec.ClusterIP == corev1.ClusterIPNone
+}
+
 func (r *LimitadorReconciler) reconcilePVC(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
 	ctx, span := r.Tracer().StartResourceSpan(ctx, "PersistentVolumeClaim", limitadorObj.Namespace, limitador.PVCName(limitadorObj))
 	defer span.End()
@@ -348,7 +524,7 @@ func (r *LimitadorReconciler) reconcilePVC(ctx context.Context, limitadorObj *li
 		return err
 	}
 
-	err = r.ReconcilePersistentVolumeClaim(ctx, pvc)
+	err = r.reconcileStoragePVC(ctx, limitadorObj, pvc)
 	logger.V(1).Info("reconcile pvc", "error", err)
 	if err != nil {
 		observability.RecordError(span, err, "failed to reconcile PVC")
@@ -359,8 +535,10 @@ func (r *LimitadorReconciler) reconcilePVC(ctx context.Context, limitadorObj *li
 	return nil
 }
 
-func (r *LimitadorReconciler) reconcileLimitsConfigMap(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
-	ctx, span := r.Tracer().StartResourceSpan(ctx, "ConfigMap", limitadorObj.Namespace, limitador.LimitsConfigMapName(limitadorObj))
+// reconcileManagedRedis reconciles the Redis instance deployed for the managed redis storage,
+// or deletes it when the storage is not managed
+func (r *LimitadorReconciler) reconcileManagedRedis(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	ctx, span := r.Tracer().StartResourceSpan(ctx, "ManagedRedis", limitadorObj.Namespace, limitador.ManagedRedisName(limitadorObj))
 	defer span.End()
 
 	logger, err := logr.FromContext(ctx)
@@ -368,27 +546,105 @@ func (r *LimitadorReconciler) reconcileLimitsConfigMap(ctx context.Context, limi
 		return err
 	}
 
-	limitsConfigMap, err := limitador.LimitsConfigMap(limitadorObj)
-	if err != nil {
-		observability.RecordError(span, err, "failed to create limits ConfigMap")
-		return err
-	}
-	if err := r.SetOwnerReference(limitadorObj, limitsConfigMap); err != nil {
+	pvc := limitador.ManagedRedisPVC(limitadorObj)
+	if err := r.SetOwnerReference(limitadorObj, pvc); err != nil {
 		observability.RecordError(span, err, "failed to set owner reference")
 		return err
 	}
 
-	err = r.ReconcileConfigMap(ctx, limitsConfigMap)
-	logger.V(1).Info("reconcile limits ConfigMap", "error", err)
+	err = r.reconcileStoragePVC(ctx, limitadorObj, pvc)
+	logger.V(1).Info("reconcile managed redis pvc", "error", err)
 	if err != nil {
-		observability.RecordError(span, err, "failed to reconcile limits ConfigMap")
+		observability.RecordError(span, err, "failed to reconcile managed redis PVC")
 		return err
 	}
 
+	for _, obj := range []client.Object{
+		limitador.ManagedRedisService(limitadorObj),
+		limitador.ManagedRedisStatefulSet(limitadorObj),
+		limitador.ManagedRedisSecret(limitadorObj),
+	} {
+		if err := r.SetOwnerReference(limitadorObj, obj); err != nil {
+			observability.RecordError(span, err, "failed to set owner reference")
+			return err
+		}
+
+		err = r.ReconcileResource(ctx, obj)
+		logger.V(1).Info("reconcile managed redis", "kind", obj.GetObjectKind().GroupVersionKind().Kind, "error", err)
+		if err != nil {
+			observability.RecordError(span, err, "failed to reconcile managed redis")
+			return err
+		}
+	}
+
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
 
+func (r *LimitadorReconciler) reconcileLimitsConfigMap(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (ctrl.Result, *limitador.SourcesLimits, error) {
+	ctx, span := r.Tracer().StartResourceSpan(ctx, "ConfigMap", limitadorObj.Namespace, limitador.LimitsConfigMapName(limitadorObj))
+	defer span.End()
+
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return ctrl.Result{}, nil, err
+	}
+
+	// The limits sources are not labelled as limitador objects, hence not cached
+	sourcesLimits, err := limitador.LimitsFromSources(ctx, r.APIClientReader(), limitadorObj)
+	if err != nil {
+		observability.RecordError(span, err, "failed to read limits sources")
+		return ctrl.Result{}, nil, err
+	}
+
+	definitionList := &limitadorv1alpha1.RateLimitDefinitionList{}
+	if err := r.Client().List(ctx, definitionList); err != nil {
+		observability.RecordError(span, err, "failed to list RateLimitDefinitions")
+		return ctrl.Result{}, &sourcesLimits, err
+	}
+	definitionsLimits := limitador.AggregateRateLimitDefinitions(limitadorObj, sourcesLimits.Limits, definitionList.Items)
+
+	limitsConfigMap, err := limitador.LimitsConfigMap(limitadorObj, append(sourcesLimits.Limits, definitionsLimits.Limits...))
+	if err != nil {
+		observability.RecordError(span, err, "failed to create limits ConfigMap")
+		return ctrl.Result{}, &sourcesLimits, err
+	}
+	if err := r.SetOwnerReference(limitadorObj, limitsConfigMap); err != nil {
+		observability.RecordError(span, err, "failed to set owner reference")
+		return ctrl.Result{}, &sourcesLimits, err
+	}
+
+	// Do not ship limits limitador would fail to load, nor drop the limits of a source failing to load:
+	// limitador keeps the last limits rendered. The errors are reported in the status.
+	var result ctrl.Result
+	errs := limitadorObj.ValidateLimits()
+	if errs = append(errs, sourcesLimits.Errors...); len(errs) > 0 {
+		logger.Info("invalid limits, limits ConfigMap not updated", "error", errs.ToAggregate().Error())
+	} else {
+		result, err = r.reconcileLimitsRollout(ctx, limitadorObj, limitsConfigMap)
+		if err != nil {
+			observability.RecordError(span, err, "failed to reconcile limits ConfigMap")
+			return ctrl.Result{}, &sourcesLimits, err
+		}
+	}
+
+	for idx := range definitionList.Items {
+		definition := &definitionList.Items[idx]
+		limitsStatus, ok := definitionsLimits.Statuses[client.ObjectKeyFromObject(definition)]
+		if !ok {
+			continue
+		}
+		newStatus := calculateRateLimitDefinitionStatus(definition, limitsStatus, definitionsLimits.NotAllowed[client.ObjectKeyFromObject(definition)])
+		if err := updateRateLimitDefinitionStatus(ctx, r.BaseReconciler, definition, newStatus); err != nil {
+			observability.RecordError(span, err, "failed to update RateLimitDefinition status")
+			return ctrl.Result{}, &sourcesLimits, err
+		}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return result, &sourcesLimits, nil
+}
+
 func (r *LimitadorReconciler) getDeploymentOptions(ctx context.Context, limObj *limitadorv1alpha1.Limitador) (limitador.DeploymentOptions, error) {
 	deploymentOptions := limitador.DeploymentOptions{}
 
@@ -401,10 +657,13 @@ func (r *LimitadorReconciler) getDeploymentOptions(ctx context.Context, limObj *
 	deploymentOptions.VolumeMounts = limitador.DeploymentVolumeMounts(deploymentStorageOptions)
 	deploymentOptions.Volumes = limitador.DeploymentVolumes(limObj, deploymentStorageOptions)
 	deploymentOptions.DeploymentStrategy = deploymentStorageOptions.DeploymentStrategy
+	deploymentOptions.PodAnnotations = map[string]string{}
+	helpers.MergeMapStringString(&deploymentOptions.PodAnnotations, deploymentStorageOptions.PodAnnotations)
 	deploymentOptions.EnvVar, err = r.getDeploymentEnvVar(limObj)
 	if err != nil {
 		return deploymentOptions, err
 	}
+	deploymentOptions.EnvVar = append(deploymentOptions.EnvVar, deploymentStorageOptions.EnvVar...)
 	deploymentOptions.ImagePullSecrets = r.getDeploymentImagePullSecrets(limObj)
 
 	return deploymentOptions, nil
@@ -413,11 +672,13 @@ func (r *LimitadorReconciler) getDeploymentOptions(ctx context.Context, limObj *
 func (r *LimitadorReconciler) getDeploymentStorageOptions(ctx context.Context, limObj *limitadorv1alpha1.Limitador) (limitador.DeploymentStorageOptions, error) {
 	if limObj.Spec.Storage != nil {
 		if limObj.Spec.Storage.Redis != nil {
-			return limitador.RedisDeploymentOptions(ctx, r.Client(), limObj.Namespace, *limObj.Spec.Storage.Redis)
+			redisObj := *limObj.Spec.Storage.Redis
+			redisObj.ConfigSecretRef = limitador.ManagedRedisConfigSecretRef(limObj, redisObj)
+			return limitador.RedisDeploymentOptions(ctx, r.APIClientReader(), limObj.Namespace, redisObj)
 		}
 
 		if limObj.Spec.Storage.RedisCached != nil {
-			return limitador.RedisCachedDeploymentOptions(ctx, r.Client(), limObj.Namespace, *limObj.Spec.Storage.RedisCached)
+			return limitador.RedisCachedDeploymentOptions(ctx, r.APIClientReader(), limObj.Namespace, *limObj.Spec.Storage.RedisCached)
 		}
 
 		if limObj.Spec.Storage.Disk != nil {
@@ -433,7 +694,7 @@ func (r *LimitadorReconciler) getDeploymentStorageOptions(ctx context.Context, l
 func (r *LimitadorReconciler) getDeploymentEnvVar(limObj *limitadorv1alpha1.Limitador) ([]corev1.EnvVar, error) {
 	if limObj.Spec.Storage != nil {
 		if limObj.Spec.Storage.Redis != nil {
-			return limitador.DeploymentEnvVar(limObj.Spec.Storage.Redis.ConfigSecretRef)
+			return limitador.DeploymentEnvVar(limitador.ManagedRedisConfigSecretRef(limObj, *limObj.Spec.Storage.Redis))
 		}
 
 		if limObj.Spec.Storage.RedisCached != nil {
@@ -444,16 +705,173 @@ func (r *LimitadorReconciler) getDeploymentEnvVar(limObj *limitadorv1alpha1.Limi
 	return nil, nil
 }
 
+// referencedSecretNames returns the names of the secrets the limitador deployment depends on
+func referencedSecretNames(limObj *limitadorv1alpha1.Limitador) []string {
+	secretRefs := []*corev1.LocalObjectReference{}
+
+	var redisTLS *limitadorv1alpha1.RedisTLS
+	if limObj.Spec.Storage != nil {
+		if limObj.Spec.Storage.Redis != nil {
+			secretRefs = append(secretRefs, limitador.ManagedRedisConfigSecretRef(limObj, *limObj.Spec.Storage.Redis))
+			redisTLS = limObj.Spec.Storage.Redis.TLS
+		} else if limObj.Spec.Storage.RedisCached != nil {
+			secretRefs = append(secretRefs, limObj.Spec.Storage.RedisCached.ConfigSecretRef)
+			redisTLS = limObj.Spec.Storage.RedisCached.TLS
+		}
+	}
+
+	if redisTLS != nil && redisTLS.CABundle != nil && redisTLS.CABundle.SecretKeyRef != nil {
+		secretRefs = append(secretRefs, &redisTLS.CABundle.SecretKeyRef.LocalObjectReference)
+	}
+
+	names := []string{}
+	for _, secretRef := range secretRefs {
+		if secretRef != nil {
+			names = append(names, secretRef.Name)
+		}
+	}
+
+	return names
+}
+
+// secretToLimitadors maps a Secret event to the Limitador objects referencing it
+func (r *LimitadorReconciler) secretToLimitadors(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.referencingLimitadors(ctx, "secret", obj, func(limObj *limitadorv1alpha1.Limitador) []string {
+		return append(referencedSecretNames(limObj), limitador.LimitsSourceSecretNames(limObj)...)
+	})
+}
+
+// configMapToLimitadors maps a ConfigMap event to the Limitador objects loading limits
+// or the Redis CA bundle from it
+func (r *LimitadorReconciler) configMapToLimitadors(ctx context.Context, obj *metav1.PartialObjectMetadata) []reconcile.Request {
+	return r.referencingLimitadors(ctx, "configmap", obj, func(limObj *limitadorv1alpha1.Limitador) []string {
+		return append(limitador.LimitsSourceConfigMapNames(limObj), limitador.RedisCABundleConfigMapNames(limObj)...)
+	})
+}
+
+// referencingLimitadors returns the Limitador objects of the namespace of the object whose referenced names include it
+func (r *LimitadorReconciler) referencingLimitadors(ctx context.Context, kind string, obj client.Object, referencedNames func(*limitadorv1alpha1.Limitador) []string) []reconcile.Request {
+	logger := r.Logger().WithValues(kind, client.ObjectKeyFromObject(obj))
+
+	limitadorList := &limitadorv1alpha1.LimitadorList{}
+	if err := r.Client().List(ctx, limitadorList, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error(err, "failed to list limitador objects")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for idx := range limitadorList.Items {
+		limObj := &limitadorList.Items[idx]
+		if !slices.Contains(referencedNames(limObj), obj.GetName()) {
+			continue
+		}
+		logger.V(1).Info(kind+" referenced by limitador", "limitador", client.ObjectKeyFromObject(limObj))
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(limObj)})
+	}
+
+	return requests
+}
+
 func (r *LimitadorReconciler) getDeploymentImagePullSecrets(limObj *limitadorv1alpha1.Limitador) []corev1.LocalObjectReference {
 	return limObj.Spec.ImagePullSecrets
 }
 
+// rateLimitDefinitionEventHandler maps a RateLimitDefinition event to the Limitador object it references.
+// On update, the Limitador object previously referenced is enqueued as well, to drop the limits
+// of the definition when the limitador reference changed.
+func rateLimitDefinitionEventHandler() handler.EventHandler {
+	enqueue := func(q workqueue.TypedRateLimitingInterface[reconcile.Request], obj client.Object) {
+		if definition, ok := obj.(*limitadorv1alpha1.RateLimitDefinition); ok {
+			q.Add(reconcile.Request{NamespacedName: definition.LimitadorKey()})
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.Object)
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.ObjectOld)
+			enqueue(q, e.ObjectNew)
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.Object)
+		},
+		GenericFunc: func(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(q, e.Object)
+		},
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LimitadorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr)
+
+	// The monitors are only watched when the monitoring.coreos.com CRDs are installed
+	// at startup, as watching a missing kind fails
+	for _, monitor := range []client.Object{&monitoringv1.ServiceMonitor{}, &monitoringv1.PodMonitor{}} {
+		gvk, err := apiutil.GVKForObject(monitor, mgr.GetScheme())
+		if err != nil {
+			return err
+		}
+		installed, err := r.monitorKindInstalled(gvk.Kind)
+		if err != nil {
+			return err
+		}
+		if installed {
+			controllerBuilder = controllerBuilder.Owns(monitor)
+		}
+	}
+
+	// The ConfigMaps cached by the manager are restricted to the ones labelled as limitador objects.
+	// The metadata of the others is cached apart to watch the limits sources and the Redis CA bundle.
+	configMapMetadataCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		HTTPClient: mgr.GetHTTPClient(),
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(configMapMetadataCache); err != nil {
+		return err
+	}
+	configMapMetadata := &metav1.PartialObjectMetadata{}
+	configMapMetadata.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+
+	return controllerBuilder.
 		For(&limitadorv1alpha1.Limitador{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Service{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&discoveryv1.EndpointSlice{}).
+		// Only the metadata of the secrets is cached, the secrets are read from the API server
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.secretToLimitadors), builder.OnlyMetadata).
+		Watches(&limitadorv1alpha1.RateLimitDefinition{},
+			rateLimitDefinitionEventHandler(),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		WatchesRawSource(source.Kind(configMapMetadataCache, configMapMetadata,
+			handler.TypedEnqueueRequestsFromMapFunc(r.configMapToLimitadors),
+		)).
 		Complete(r)
 }
+
+// storageType returns the storage type of the limitador instance, as reported in traces and metrics
+func storageType(limitadorObj *limitadorv1alpha1.Limitador) string {
+	if limitadorObj.Spec.Storage != nil {
+		if limitadorObj.Spec.Storage.RedisCached != nil {
+			return "redis-cached"
+		} else if limitadorObj.Spec.Storage.Redis != nil {
+			return "redis"
+		} else if limitadorObj.Spec.Storage.Disk != nil {
+			return "disk"
+		}
+	}
+
+	return "memory"
+}
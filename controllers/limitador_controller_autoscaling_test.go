@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages HorizontalPodAutoscaler", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("CEL validation on autoscaling configuration", func() {
+		It("Should not allow replicas and autoscaling", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.Replicas = ptr.To(2)
+			limitadorObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{MaxReplicas: 3}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("replicas and autoscaling are mutually exclusive"))
+		}, specTimeOut)
+
+		It("Should not allow autoscaling with disk storage", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.Storage = &limitadorv1alpha1.Storage{Disk: &limitadorv1alpha1.DiskSpec{}}
+			limitadorObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{MaxReplicas: 3}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("disk storage does not allow autoscaling"))
+		}, specTimeOut)
+
+		It("Should not allow minReplicas greater than maxReplicas", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{MinReplicas: ptr.To(int32(4)), MaxReplicas: 3}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("minReplicas must not be greater than maxReplicas"))
+		}, specTimeOut)
+	})
+
+	Context("Creating a new Limitador object with autoscaling", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{
+				MinReplicas:                    ptr.To(int32(1)),
+				MaxReplicas:                    3,
+				TargetCPUUtilizationPercentage: ptr.To(int32(70)),
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should create the HorizontalPodAutoscaler and delete it when autoscaling is removed", func(ctx SpecContext) {
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			hpaKey := types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.HorizontalPodAutoscalerName(limitadorObj),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, hpaKey, hpa)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(hpa.Spec.ScaleTargetRef.Name).To(Equal(limitador.DeploymentName(limitadorObj)))
+			Expect(hpa.Spec.MaxReplicas).To(Equal(int32(3)))
+			Expect(hpa.Spec.Metrics).To(HaveLen(1))
+			Expect(hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(ptr.To(int32(70))))
+			Expect(hpa.OwnerReferences).To(HaveLen(1))
+			Expect(hpa.OwnerReferences[0].Name).To(Equal(limitadorObj.Name))
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.Autoscaling = nil
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, hpaKey, hpa)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
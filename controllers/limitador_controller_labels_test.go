@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages custom labels and annotations", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("CEL validation on custom labels", func() {
+		It("Should not allow overriding the selector labels", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.CommonLabels = map[string]string{"app": "other"}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("labels app and limitador-resource are reserved"))
+
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.PodTemplate = &limitadorv1alpha1.PodTemplate{
+				Metadata: limitadorv1alpha1.PodTemplateMetadata{
+					Labels: map[string]string{"limitador-resource": "other"},
+				},
+			}
+			err = k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("labels app and limitador-resource are reserved"))
+		}, specTimeOut)
+	})
+
+	Context("Creating a new Limitador object with custom labels and annotations", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.CommonLabels = map[string]string{"cost-center": "rate-limiting"}
+			limitadorObj.Spec.PodTemplate = &limitadorv1alpha1.PodTemplate{
+				Metadata: limitadorv1alpha1.PodTemplateMetadata{
+					Labels:      map[string]string{"backup": "skip"},
+					Annotations: map[string]string{"example.com/team": "platform"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should label the owned objects and the pods", func(ctx SpecContext) {
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.DeploymentName(limitadorObj),
+			}, deployment)).To(Succeed())
+			Expect(deployment.Labels).To(HaveKeyWithValue("cost-center", "rate-limiting"))
+			Expect(deployment.Labels).NotTo(HaveKey("backup"))
+
+			service := &v1.Service{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.ServiceName(limitadorObj),
+			}, service)).To(Succeed())
+			Expect(service.Labels).To(HaveKeyWithValue("cost-center", "rate-limiting"))
+
+			cm := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.LimitsConfigMapName(limitadorObj),
+			}, cm)).To(Succeed())
+			Expect(cm.Labels).To(HaveKeyWithValue("cost-center", "rate-limiting"))
+
+			Eventually(func(g Gomega) {
+				podList := &v1.PodList{}
+				g.Expect(k8sClient.List(ctx, podList, &client.ListOptions{
+					LabelSelector: labels.SelectorFromSet(limitador.SelectorLabels(limitadorObj)),
+					Namespace:     testNamespace,
+				})).To(Succeed())
+				g.Expect(podList.Items).NotTo(BeEmpty())
+
+				for _, pod := range podList.Items {
+					g.Expect(pod.Labels).To(HaveKeyWithValue("cost-center", "rate-limiting"))
+					g.Expect(pod.Labels).To(HaveKeyWithValue("backup", "skip"))
+					g.Expect(pod.Annotations).To(HaveKeyWithValue("example.com/team", "platform"))
+					g.Expect(pod.Annotations).To(HaveKeyWithValue(limitadorv1alpha1.PodAnnotationConfigMapResourceVersion, cm.ResourceVersion))
+				}
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller loads limits from sources", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Creating a Limitador object loading limits from a ConfigMap", func() {
+		var (
+			limitadorObj *limitadorv1alpha1.Limitador
+			sourceCM     *v1.ConfigMap
+		)
+
+		inlineLimit := limitadorv1alpha1.RateLimit{
+			Conditions: []string{}, MaxValue: 10, Namespace: "inline", Seconds: 60, Variables: []string{},
+		}
+		sourcedLimit := limitadorv1alpha1.RateLimit{
+			Conditions: []string{}, MaxValue: 5, Namespace: "generated", Seconds: 60, Variables: []string{},
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			sourceData, err := yaml.Marshal([]limitadorv1alpha1.RateLimit{sourcedLimit})
+			Expect(err).NotTo(HaveOccurred())
+			sourceCM = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "generated-limits", Namespace: testNamespace},
+				Data:       map[string]string{"limits.yaml": string(sourceData)},
+			}
+			Expect(k8sClient.Create(ctx, sourceCM)).To(Succeed())
+
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Limits = []limitadorv1alpha1.RateLimit{inlineLimit}
+			limitadorObj.Spec.LimitsFrom = []limitadorv1alpha1.LimitsSource{
+				{ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: sourceCM.Name},
+					Key:                  "limits.yaml",
+				}},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		configMapLimits := func(ctx SpecContext) func(g Gomega) []limitadorv1alpha1.RateLimit {
+			return func(g Gomega) []limitadorv1alpha1.RateLimit {
+				cm := &v1.ConfigMap{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: testNamespace,
+					Name:      limitador.LimitsConfigMapName(limitadorObj),
+				}, cm)).To(Succeed())
+
+				var cmLimits []limitadorv1alpha1.RateLimit
+				g.Expect(yaml.Unmarshal([]byte(cm.Data[limitador.LimitadorConfigFileName]), &cmLimits)).To(Succeed())
+				return cmLimits
+			}
+		}
+
+		It("Should merge the limits of the source after the inline limits", func(ctx SpecContext) {
+			Eventually(configMapLimits(ctx)).WithContext(ctx).Should(Equal([]limitadorv1alpha1.RateLimit{inlineLimit, sourcedLimit}))
+		}, specTimeOut)
+
+		It("Should reload the limits when the source changes", func(ctx SpecContext) {
+			updatedLimit := sourcedLimit
+			updatedLimit.MaxValue = 50
+			sourceData, err := yaml.Marshal([]limitadorv1alpha1.RateLimit{updatedLimit})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sourceCM), sourceCM)).To(Succeed())
+				sourceCM.Data["limits.yaml"] = string(sourceData)
+				g.Expect(k8sClient.Update(ctx, sourceCM)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(configMapLimits(ctx)).WithContext(ctx).Should(Equal([]limitadorv1alpha1.RateLimit{inlineLimit, updatedLimit}))
+		}, specTimeOut)
+
+		It("Should keep the limits and report the source when it is removed", func(ctx SpecContext) {
+			Eventually(configMapLimits(ctx)).WithContext(ctx).Should(HaveLen(2))
+			Expect(k8sClient.Delete(ctx, sourceCM)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				cond := meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionLimitsValid)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Message).To(ContainSubstring("configmap generated-limits"))
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(configMapLimits(ctx)(Default)).To(HaveLen(2))
+		}, specTimeOut)
+
+		It("Should keep the last limits and report the source when it holds an invalid limit", func(ctx SpecContext) {
+			Eventually(configMapLimits(ctx)).WithContext(ctx).Should(Equal([]limitadorv1alpha1.RateLimit{inlineLimit, sourcedLimit}))
+
+			invalidLimit := sourcedLimit
+			invalidLimit.MaxValue = 50
+			invalidLimit.Seconds = 0
+			sourceData, err := yaml.Marshal([]limitadorv1alpha1.RateLimit{invalidLimit})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sourceCM), sourceCM)).To(Succeed())
+				sourceCM.Data["limits.yaml"] = string(sourceData)
+				g.Expect(k8sClient.Update(ctx, sourceCM)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				cond := meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionLimitsValid)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Message).To(ContainSubstring("spec.limitsFrom[0].limits[0].seconds"))
+			}).WithContext(ctx).Should(Succeed())
+
+			// Neither the invalid limit nor the inline limit alone are loaded
+			Consistently(configMapLimits(ctx)).WithContext(ctx).WithTimeout(10 * time.Second).
+				Should(Equal([]limitadorv1alpha1.RateLimit{inlineLimit, sourcedLimit}))
+		}, specTimeOut)
+	})
+})
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller rolls the limits out in stages", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 3)
+	)
+
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Updating the limits of a Limitador object with a limits rollout", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		initialLimit := limitadorv1alpha1.RateLimit{
+			Conditions: []string{}, MaxValue: 10, Namespace: "test-namespace", Seconds: 60, Variables: []string{},
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Limits = []limitadorv1alpha1.RateLimit{initialLimit}
+			limitadorObj.Spec.LimitsRollout = &limitadorv1alpha1.LimitsRollout{
+				CanaryReplicas: ptr.To(int32(1)),
+				BakeTime:       &metav1.Duration{Duration: 20 * time.Second},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		configMapLimits := func(ctx SpecContext, name string) func(g Gomega) []limitadorv1alpha1.RateLimit {
+			return func(g Gomega) []limitadorv1alpha1.RateLimit {
+				cm := &v1.ConfigMap{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: name}, cm)).To(Succeed())
+
+				var cmLimits []limitadorv1alpha1.RateLimit
+				g.Expect(yaml.Unmarshal([]byte(cm.Data[limitador.LimitadorConfigFileName]), &cmLimits)).To(Succeed())
+				return cmLimits
+			}
+		}
+
+		It("Should load the new limits in the canary pods first, then in every pod", func(ctx SpecContext) {
+			Expect(configMapLimits(ctx, limitador.LimitsConfigMapName(limitadorObj))(Default)).To(Equal([]limitadorv1alpha1.RateLimit{initialLimit}))
+
+			updatedLimit := initialLimit
+			updatedLimit.MaxValue = 20
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.Limits = []limitadorv1alpha1.RateLimit{updatedLimit}
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(configMapLimits(ctx, limitador.LimitsCanaryConfigMapName(limitadorObj))).WithContext(ctx).
+				Should(Equal([]limitadorv1alpha1.RateLimit{updatedLimit}))
+			Expect(configMapLimits(ctx, limitador.LimitsConfigMapName(limitadorObj))(Default)).To(Equal([]limitadorv1alpha1.RateLimit{initialLimit}))
+
+			Eventually(func(g Gomega) {
+				canaryDeployment := &appsv1.Deployment{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: testNamespace,
+					Name:      limitador.LimitsCanaryDeploymentName(limitadorObj),
+				}, canaryDeployment)).To(Succeed())
+				g.Expect(canaryDeployment.Spec.Replicas).To(Equal(ptr.To(int32(1))))
+
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(updatedLimitador.Status.LimitsRollout).NotTo(BeNil())
+				g.Expect(updatedLimitador.Status.LimitsRollout.Phase).To(BeElementOf(
+					limitadorv1alpha1.LimitsRolloutPhaseCanary, limitadorv1alpha1.LimitsRolloutPhaseBaking))
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(configMapLimits(ctx, limitador.LimitsConfigMapName(limitadorObj))).WithContext(ctx).
+				Should(Equal([]limitadorv1alpha1.RateLimit{updatedLimit}))
+
+			Eventually(func(g Gomega) {
+				canaryDeployment := &appsv1.Deployment{}
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: testNamespace,
+					Name:      limitador.LimitsCanaryDeploymentName(limitadorObj),
+				}, canaryDeployment)
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(updatedLimitador.Status.LimitsRollout).To(BeNil())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+
+		It("Should keep the previous limits when the canary pods never get ready", func(ctx SpecContext) {
+			updatedLimit := initialLimit
+			updatedLimit.MaxValue = 20
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.Limits = []limitadorv1alpha1.RateLimit{updatedLimit}
+				// No node matches, the canary pods cannot be scheduled
+				updatedLimitador.Spec.NodeSelector = map[string]string{"limitador.kuadrant.io/unschedulable": "true"}
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(updatedLimitador.Status.LimitsRollout).NotTo(BeNil())
+				g.Expect(updatedLimitador.Status.LimitsRollout.Phase).To(Equal(limitadorv1alpha1.LimitsRolloutPhaseRolledBack))
+				g.Expect(updatedLimitador.Status.LimitsRollout.Message).To(ContainSubstring("canary pods not ready"))
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(configMapLimits(ctx, limitador.LimitsConfigMapName(limitadorObj))(Default)).To(Equal([]limitadorv1alpha1.RateLimit{initialLimit}))
+
+			Eventually(func(g Gomega) {
+				canaryDeployment := &appsv1.Deployment{}
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: testNamespace,
+					Name:      limitador.LimitsCanaryDeploymentName(limitadorObj),
+				}, canaryDeployment)
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+			Consistently(configMapLimits(ctx, limitador.LimitsConfigMapName(limitadorObj))).WithContext(ctx).
+				WithTimeout(10 * time.Second).Should(Equal([]limitadorv1alpha1.RateLimit{initialLimit}))
+		}, specTimeOut)
+	})
+})
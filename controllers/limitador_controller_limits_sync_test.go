@@ -9,6 +9,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -83,6 +85,44 @@ var _ = Describe("Limitador controller syncs limits to pod", func() {
 				Expect(pod.Annotations[limitadorv1alpha1.PodAnnotationConfigMapResourceVersion]).To(Equal(cm.ResourceVersion))
 			}
 		}, specTimeOut)
+
+		It("Should report the limits loaded by the pods", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				cond := meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionLimitsSynced)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(cond.Message).To(Equal(fmt.Sprintf("Limits loaded by %d pods", limitadorv1alpha1.DefaultReplicas)))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+
+	Context("Creating a new Limitador object whose pods load stale limits", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Annotations = map[string]string{fakeLimitadorStaleLimitsAnnotation: "true"}
+			limitadorObj.Spec.Limits = []limitadorv1alpha1.RateLimit{
+				{Conditions: []string{}, MaxValue: 10, Namespace: "test-namespace", Seconds: 60, Variables: []string{}},
+				{Conditions: []string{}, MaxValue: 5, Namespace: "test-namespace", Seconds: 1, Variables: []string{}},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should report the limits not loaded by the pods", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				cond := meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionLimitsSynced)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Reason).To(Equal("LimitsNotSynced"))
+				g.Expect(cond.Message).To(ContainSubstring("namespace test-namespace: 1 limits missing, 0 unexpected limits"))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
 	})
 
 	Context("Updating a Limitador object - multiple replicas", func() {
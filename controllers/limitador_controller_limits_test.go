@@ -6,7 +6,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
@@ -72,6 +75,20 @@ var _ = Describe("Limitador controller manages limits", func() {
 			Expect(err).To(BeNil())
 			Expect(cmLimits).To(Equal(limits))
 		}, specTimeOut)
+
+		It("Should report the limits in the status", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(updatedLimitador.Status.Limits).NotTo(BeNil())
+				g.Expect(updatedLimitador.Status.Limits.Total).To(Equal(int32(2)))
+				g.Expect(updatedLimitador.Status.Limits.Namespaces).To(Equal([]limitadorv1alpha1.NamespaceLimitsStatus{
+					{Namespace: "test-namespace", Count: 2},
+				}))
+				g.Expect(updatedLimitador.Status.Limits.ConfigHash).NotTo(BeEmpty())
+				g.Expect(updatedLimitador.Status.Limits.ConfigMapResourceVersion).NotTo(BeEmpty())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
 	})
 
 	Context("Updating limitador object with new limits", func() {
@@ -142,4 +159,104 @@ var _ = Describe("Limitador controller manages limits", func() {
 			}).WithContext(ctx).Should(Succeed())
 		}, specTimeOut)
 	})
+
+	Context("Updating limitador object with invalid limits", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		validLimits := []limitadorv1alpha1.RateLimit{
+			{
+				Conditions: []string{"req.method == 'GET'"},
+				MaxValue:   10,
+				Namespace:  "test-namespace",
+				Seconds:    60,
+			},
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Limits = validLimits
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should report the errors and keep the previous configmap", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionLimitsValid)).To(BeTrue())
+
+				updatedLimitador.Spec.Limits = []limitadorv1alpha1.RateLimit{
+					{
+						Conditions: []string{"req.method == 'POST"},
+						MaxValue:   5,
+						Namespace:  "test-namespace",
+						Seconds:    60,
+					},
+				}
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				cond := meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionLimitsValid)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Reason).To(Equal("InvalidLimits"))
+				g.Expect(cond.Message).To(ContainSubstring("spec.limits[0].conditions[0]"))
+			}).WithContext(ctx).Should(Succeed())
+
+			cm := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx,
+				types.NamespacedName{
+					Namespace: testNamespace,
+					Name:      limitador.LimitsConfigMapName(limitadorObj),
+				}, cm)).To(Succeed())
+
+			var cmLimits []limitadorv1alpha1.RateLimit
+			Expect(yaml.Unmarshal([]byte(cm.Data[limitador.LimitadorConfigFileName]), &cmLimits)).To(Succeed())
+			Expect(cmLimits).To(Equal(validLimits))
+		}, specTimeOut)
+	})
+
+	Context("Creating a Limitador object with shadow limits", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Limits = []limitadorv1alpha1.RateLimit{
+				{Conditions: []string{}, MaxValue: 10, Namespace: "test-namespace", Seconds: 60, Variables: []string{}, Name: "enforced"},
+				{Conditions: []string{}, MaxValue: 5, Namespace: "test-namespace", Seconds: 60, Variables: []string{}, Name: "shadow",
+					Mode: limitadorv1alpha1.LimitModeShadow},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should render the shadow limits unenforced and count them in the status", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				cm := &v1.ConfigMap{}
+				g.Expect(k8sClient.Get(ctx,
+					types.NamespacedName{
+						Namespace: testNamespace,
+						Name:      limitador.LimitsConfigMapName(limitadorObj),
+					}, cm)).To(Succeed())
+
+				var cmLimits []limitadorv1alpha1.RateLimit
+				g.Expect(yaml.Unmarshal([]byte(cm.Data[limitador.LimitadorConfigFileName]), &cmLimits)).To(Succeed())
+				g.Expect(cmLimits).To(HaveLen(2))
+				g.Expect(cmLimits[0].MaxValue).To(Equal(10))
+				g.Expect(cmLimits[1].MaxValue).To(Equal(limitador.ShadowMaxValue))
+				g.Expect(cmLimits[1].Mode).To(BeEmpty())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(updatedLimitador.Status.Limits).NotTo(BeNil())
+				g.Expect(updatedLimitador.Status.Limits.Total).To(Equal(int32(2)))
+				g.Expect(updatedLimitador.Status.Limits.Shadow).To(Equal(int32(1)))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
 })
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages Redis", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Creating a new Limitador object with managed redis storage", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Storage = &limitadorv1alpha1.Storage{
+				Redis: &limitadorv1alpha1.Redis{
+					Managed: &limitadorv1alpha1.ManagedRedis{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+		})
+
+		It("Should create the redis objects and wire the generated secret", func(ctx SpecContext) {
+			key := types.NamespacedName{Namespace: testNamespace, Name: limitador.ManagedRedisName(limitadorObj)}
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, key, &appsv1.StatefulSet{})).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, key, &corev1.Service{})).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, key, &corev1.PersistentVolumeClaim{})).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			secret := &corev1.Secret{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, key, secret)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue("URL",
+				[]byte("redis://"+limitador.ManagedRedisName(limitadorObj)+"."+testNamespace+".svc:6379")))
+
+			deployment := appsv1.Deployment{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: testNamespace,
+					Name:      limitador.DeploymentName(limitadorObj),
+				}, &deployment)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(1))
+			Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElements("redis", "$(LIMITADOR_OPERATOR_REDIS_URL)"))
+			Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+				Name: "LIMITADOR_OPERATOR_REDIS_URL",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+						Key:                  "URL",
+					},
+				},
+			}))
+		}, specTimeOut)
+
+		It("Should delete the redis objects when the storage is no longer managed", func(ctx SpecContext) {
+			key := types.NamespacedName{Namespace: testNamespace, Name: limitador.ManagedRedisName(limitadorObj)}
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, key, &appsv1.StatefulSet{})).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updated := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updated)).To(Succeed())
+				updated.Spec.Storage = nil
+				g.Expect(k8sClient.Update(ctx, updated)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, key, &appsv1.StatefulSet{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				err = k8sClient.Get(ctx, key, &corev1.Secret{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+
+	Context("Managed redis with a config secret", func() {
+		It("resource is rejected", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.Storage = &limitadorv1alpha1.Storage{
+				Redis: &limitadorv1alpha1.Redis{
+					ConfigSecretRef: &corev1.LocalObjectReference{Name: "redis"},
+					Managed:         &limitadorv1alpha1.ManagedRedis{},
+				},
+			}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("managed is mutually exclusive with configSecretRef and tls"))
+		}, specTimeOut)
+	})
+})
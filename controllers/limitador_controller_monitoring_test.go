@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages Prometheus monitors", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	// The monitoring.coreos.com CRDs may or may not be installed in the test cluster
+	serviceMonitorInstalled := func() bool {
+		_, err := k8sClient.RESTMapper().RESTMapping(schema.GroupKind{
+			Group: monitoringv1.SchemeGroupVersion.Group,
+			Kind:  monitoringv1.ServiceMonitorsKind,
+		}, monitoringv1.SchemeGroupVersion.Version)
+		if meta.IsNoMatchError(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Creating a new Limitador object with a ServiceMonitor", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Monitoring = &limitadorv1alpha1.Monitoring{
+				ServiceMonitor: &limitadorv1alpha1.ServiceMonitorSpec{
+					Interval: ptr.To(monitoringv1.Duration("30s")),
+					Labels:   map[string]string{"release": "prometheus"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should reconcile the ServiceMonitor or report the missing CRD", func(ctx SpecContext) {
+			if !serviceMonitorInstalled() {
+				Eventually(func(g Gomega) {
+					updatedLimitador := &limitadorv1alpha1.Limitador{}
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+					cond := meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionMonitoring)
+					g.Expect(cond).NotTo(BeNil())
+					g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(cond.Reason).To(Equal("MonitoringCRDNotFound"))
+				}).WithContext(ctx).Should(Succeed())
+				return
+			}
+
+			serviceMonitorKey := types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.MonitorName(limitadorObj),
+			}
+			serviceMonitor := &monitoringv1.ServiceMonitor{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, serviceMonitorKey, serviceMonitor)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+			Expect(serviceMonitor.Labels).To(HaveKeyWithValue("release", "prometheus"))
+			Expect(serviceMonitor.Spec.Endpoints).To(HaveLen(1))
+			Expect(serviceMonitor.Spec.Endpoints[0].Interval).To(Equal(monitoringv1.Duration("30s")))
+			Expect(serviceMonitor.OwnerReferences).To(HaveLen(1))
+			Expect(serviceMonitor.OwnerReferences[0].Name).To(Equal(limitadorObj.Name))
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionMonitoring)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.Monitoring = nil
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, serviceMonitorKey, serviceMonitor)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(meta.FindStatusCondition(updatedLimitador.Status.Conditions, limitadorv1alpha1.StatusConditionMonitoring)).To(BeNil())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
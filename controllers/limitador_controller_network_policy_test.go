@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages NetworkPolicy", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Creating a new Limitador object with a network policy", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		gatewayPeer := networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "gateway-system"},
+			},
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.NetworkPolicy = &limitadorv1alpha1.NetworkPolicySpec{
+				GRPC:   []networkingv1.NetworkPolicyPeer{gatewayPeer},
+				Egress: true,
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should create the NetworkPolicy and delete it when removed from the spec", func(ctx SpecContext) {
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			networkPolicyKey := types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.NetworkPolicyName(limitadorObj),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, networkPolicyKey, networkPolicy)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(Equal(limitador.SelectorLabels(limitadorObj)))
+			Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress))
+			Expect(networkPolicy.Spec.Ingress).To(HaveLen(2))
+			Expect(networkPolicy.Spec.Ingress[0].From).To(Equal([]networkingv1.NetworkPolicyPeer{gatewayPeer}))
+			Expect(networkPolicy.Spec.Ingress[0].Ports[0].Port.IntVal).To(Equal(limitadorObj.GRPCPort()))
+			// in memory storage: DNS only
+			Expect(networkPolicy.Spec.Egress).To(HaveLen(1))
+			Expect(networkPolicy.OwnerReferences).To(HaveLen(1))
+			Expect(networkPolicy.OwnerReferences[0].Name).To(Equal(limitadorObj.Name))
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.NetworkPolicy = nil
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, networkPolicyKey, networkPolicy)
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages probes", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Creating a new Limitador object with custom probes", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Probes = &limitadorv1alpha1.Probes{
+				Liveness: &limitadorv1alpha1.ProbeTimings{PeriodSeconds: ptr.To(int32(20))},
+				Startup:  &limitadorv1alpha1.ProbeTimings{FailureThreshold: ptr.To(int32(30))},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should create a new deployment with the custom probes", func(ctx SpecContext) {
+			deployment := appsv1.Deployment{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(
+					ctx,
+					types.NamespacedName{
+						Namespace: testNamespace,
+						Name:      limitador.DeploymentName(limitadorObj),
+					},
+					&deployment)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.LivenessProbe.PeriodSeconds).To(Equal(int32(20)))
+			Expect(container.ReadinessProbe.PeriodSeconds).To(Equal(int32(10)))
+			Expect(container.StartupProbe).NotTo(BeNil())
+			Expect(container.StartupProbe.FailureThreshold).To(Equal(int32(30)))
+			Expect(container.StartupProbe.HTTPGet.Path).To(Equal(limitador.StatusEndpoint))
+		}, specTimeOut)
+	})
+})
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+var _ = Describe("Limitador controller manages changes of the disk storage PVC", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Shrinking the PVC of the disk storage", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = limitadorWithDiskStorage(testNamespace)
+			limitadorObj.Spec.Storage.Disk.PVC = &limitadorv1alpha1.PVCGenericSpec{
+				Resources: &limitadorv1alpha1.PersistentVolumeClaimResources{Requests: resource.MustParse("2Gi")},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				g.Expect(meta.IsStatusConditionFalse(existing.Status.Conditions, limitadorv1alpha1.StatusConditionStorageConfigDrift)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should report the storage config drift", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				existing.Spec.Storage.Disk.PVC.Resources.Requests = resource.MustParse("1Gi")
+				g.Expect(k8sClient.Update(ctx, existing)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				cond := meta.FindStatusCondition(existing.Status.Conditions, limitadorv1alpha1.StatusConditionStorageConfigDrift)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Reason).To(Equal("PVCConfigDrift"))
+				g.Expect(cond.Message).To(ContainSubstring("storage request cannot shrink from 2Gi to 1Gi"))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
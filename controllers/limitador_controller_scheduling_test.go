@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages pod scheduling and security", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Creating a new Limitador object with pod scheduling and security settings", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		tolerations := []v1.Toleration{
+			{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "limitador", Effect: v1.TaintEffectNoSchedule},
+		}
+		nodeSelector := map[string]string{"kubernetes.io/os": "linux"}
+		podSecurityContext := &v1.PodSecurityContext{
+			SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+		}
+		securityContext := &v1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			serviceAccount := &v1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "limitador", Namespace: testNamespace},
+			}
+			Expect(k8sClient.Create(ctx, serviceAccount)).Should(Succeed())
+
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Tolerations = tolerations
+			limitadorObj.Spec.NodeSelector = nodeSelector
+			limitadorObj.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: v1.ScheduleAnyway},
+			}
+			limitadorObj.Spec.ServiceAccountName = ptr.To(serviceAccount.Name)
+			limitadorObj.Spec.PodSecurityContext = podSecurityContext
+			limitadorObj.Spec.SecurityContext = securityContext
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should create a new deployment with the custom pod settings", func(ctx SpecContext) {
+			deployment := appsv1.Deployment{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(
+					ctx,
+					types.NamespacedName{
+						Namespace: testNamespace,
+						Name:      limitador.DeploymentName(limitadorObj),
+					},
+					&deployment)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Tolerations).To(Equal(tolerations))
+			Expect(podSpec.NodeSelector).To(Equal(nodeSelector))
+			Expect(podSpec.TopologySpreadConstraints).To(HaveLen(1))
+			Expect(podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(Equal(limitador.SelectorLabels(limitadorObj)))
+			Expect(podSpec.ServiceAccountName).To(Equal("limitador"))
+			Expect(podSpec.SecurityContext.SeccompProfile).To(Equal(podSecurityContext.SeccompProfile))
+			Expect(podSpec.Containers[0].SecurityContext).To(Equal(securityContext))
+		}, specTimeOut)
+
+		It("Should remove the tolerations from the deployment when removed from the spec", func(ctx SpecContext) {
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.Tolerations = nil
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				deployment := appsv1.Deployment{}
+				g.Expect(k8sClient.Get(
+					ctx,
+					types.NamespacedName{
+						Namespace: testNamespace,
+						Name:      limitador.DeploymentName(limitadorObj),
+					},
+					&deployment)).To(Succeed())
+				g.Expect(deployment.Spec.Template.Spec.Tolerations).To(BeEmpty())
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
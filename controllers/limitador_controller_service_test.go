@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages the Service", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("CEL validation on the service", func() {
+		It("Should not allow externalTrafficPolicy with a ClusterIP service", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.Service = &limitadorv1alpha1.ServiceSpec{
+				ExternalTrafficPolicy: ptr.To(v1.ServiceExternalTrafficPolicyLocal),
+			}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("externalTrafficPolicy requires a NodePort or LoadBalancer service"))
+		}, specTimeOut)
+	})
+
+	Context("Updating the service of a Limitador object", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should recreate the headless service as a NodePort service", func(ctx SpecContext) {
+			serviceKey := types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.ServiceName(limitadorObj),
+			}
+			service := &v1.Service{}
+			Expect(k8sClient.Get(ctx, serviceKey, service)).To(Succeed())
+			Expect(service.Spec.ClusterIP).To(Equal(v1.ClusterIPNone))
+			oldUID := service.UID
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				updatedLimitador.Spec.Service = &limitadorv1alpha1.ServiceSpec{
+					Type:                  ptr.To(v1.ServiceTypeNodePort),
+					Annotations:           map[string]string{"example.com/exposed": "true"},
+					ExternalTrafficPolicy: ptr.To(v1.ServiceExternalTrafficPolicyLocal),
+				}
+				g.Expect(k8sClient.Update(ctx, updatedLimitador)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, serviceKey, service)).To(Succeed())
+				g.Expect(service.UID).NotTo(Equal(oldUID))
+				g.Expect(service.Spec.Type).To(Equal(v1.ServiceTypeNodePort))
+				g.Expect(service.Spec.ClusterIP).NotTo(Equal(v1.ClusterIPNone))
+				g.Expect(service.Spec.ExternalTrafficPolicy).To(Equal(v1.ServiceExternalTrafficPolicyLocal))
+				g.Expect(service.Annotations).To(HaveKeyWithValue("example.com/exposed", "true"))
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				updatedLimitador := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), updatedLimitador)).To(Succeed())
+				g.Expect(updatedLimitador.Status.Service).NotTo(BeNil())
+				g.Expect(updatedLimitador.Status.Service.Host).To(Equal(
+					limitador.ServiceName(limitadorObj) + "." + testNamespace + ".svc.cluster.local"))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("Limitador controller manages the storage retain policy", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+	var testNamespace string
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+	}, nodeTimeOut)
+
+	Context("Deleting a Limitador object with retained disk storage", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = limitadorWithDiskStorage(testNamespace)
+			limitadorObj.Spec.Storage.Disk.RetainPolicy = ptr.To(limitadorv1alpha1.RetainPolicyRetain)
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				g.Expect(existing.Finalizers).To(ContainElement(limitadorv1alpha1.LimitadorFinalizer))
+			}).WithContext(ctx).Should(Succeed())
+		})
+
+		It("Should keep the PVC and adopt it again when the Limitador object is recreated", func(ctx SpecContext) {
+			pvcKey := types.NamespacedName{Namespace: testNamespace, Name: limitador.PVCName(limitadorObj)}
+			Eventually(func(g Gomega) {
+				pvc := &v1.PersistentVolumeClaim{}
+				g.Expect(k8sClient.Get(ctx, pvcKey, pvc)).To(Succeed())
+				g.Expect(metav1.IsControlledBy(pvc, limitadorObj)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, limitadorObj)).To(Succeed())
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), &limitadorv1alpha1.Limitador{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}).WithContext(ctx).Should(Succeed())
+
+			pvc := &v1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, pvcKey, pvc)).To(Succeed())
+			Expect(pvc.GetDeletionTimestamp()).To(BeNil())
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+			Expect(pvc.Annotations).To(HaveKeyWithValue(helpers.ReleasedByAnnotation, limitadorObj.Name))
+
+			recreated := limitadorWithDiskStorage(testNamespace)
+			recreated.GenerateName = ""
+			recreated.Name = limitadorObj.Name
+			Expect(k8sClient.Create(ctx, recreated)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				pvc := &v1.PersistentVolumeClaim{}
+				g.Expect(k8sClient.Get(ctx, pvcKey, pvc)).To(Succeed())
+				g.Expect(metav1.IsControlledBy(pvc, recreated)).To(BeTrue())
+				g.Expect(pvc.Annotations).NotTo(HaveKey(helpers.ReleasedByAnnotation))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+
+	Context("Creating a Limitador object with disk storage deleted along with it", func() {
+		It("Should not add the finalizer", func(ctx SpecContext) {
+			limitadorObj := limitadorWithDiskStorage(testNamespace)
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				g.Expect(existing.Status.ObservedGeneration).To(Equal(existing.Generation))
+				g.Expect(existing.Finalizers).To(BeEmpty())
+			}).WithContext(ctx).Should(Succeed())
+
+			pvc := &v1.PersistentVolumeClaim{}
+			pvcKey := types.NamespacedName{Namespace: testNamespace, Name: limitador.PVCName(limitadorObj)}
+			Expect(k8sClient.Get(ctx, pvcKey, pvc)).To(Succeed())
+			Expect(pvc.Annotations).To(HaveKeyWithValue(helpers.RetainPolicyAnnotation, string(limitadorv1alpha1.RetainPolicyDelete)))
+		}, specTimeOut)
+	})
+
+	Context("Switching a Limitador object with retained disk storage to in-memory storage", func() {
+		It("Should release the PVC and remove the finalizer", func(ctx SpecContext) {
+			limitadorObj := limitadorWithDiskStorage(testNamespace)
+			limitadorObj.Spec.Storage.Disk.RetainPolicy = ptr.To(limitadorv1alpha1.RetainPolicyRetain)
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+
+			pvcKey := types.NamespacedName{Namespace: testNamespace, Name: limitador.PVCName(limitadorObj)}
+			Eventually(func(g Gomega) {
+				pvc := &v1.PersistentVolumeClaim{}
+				g.Expect(k8sClient.Get(ctx, pvcKey, pvc)).To(Succeed())
+				g.Expect(metav1.IsControlledBy(pvc, limitadorObj)).To(BeTrue())
+				g.Expect(pvc.Annotations).To(HaveKeyWithValue(helpers.RetainPolicyAnnotation, string(limitadorv1alpha1.RetainPolicyRetain)))
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				g.Expect(existing.Finalizers).To(ContainElement(limitadorv1alpha1.LimitadorFinalizer))
+				existing.Spec.Storage = nil
+				g.Expect(k8sClient.Update(ctx, existing)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				g.Expect(existing.Finalizers).To(BeEmpty())
+			}).WithContext(ctx).Should(Succeed())
+
+			pvc := &v1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, pvcKey, pvc)).To(Succeed())
+			Expect(pvc.GetDeletionTimestamp()).To(BeNil())
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+			Expect(pvc.Annotations).To(HaveKeyWithValue(helpers.ReleasedByAnnotation, limitadorObj.Name))
+		}, specTimeOut)
+	})
+
+	Context("Creating a Limitador object with disk storage over a PVC not released by a Limitador object", func() {
+		It("Should not adopt the PVC", func(ctx SpecContext) {
+			limitadorObj := limitadorWithDiskStorage(testNamespace)
+			limitadorObj.GenerateName = ""
+			limitadorObj.Name = "limitador-unreleased-pvc"
+
+			pvc := limitador.PVC(limitadorObj)
+			Expect(k8sClient.Create(ctx, pvc)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				existing := &limitadorv1alpha1.Limitador{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(limitadorObj), existing)).To(Succeed())
+				g.Expect(existing.Status.ObservedGeneration).To(Equal(existing.Generation))
+			}).WithContext(ctx).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				existing := &v1.PersistentVolumeClaim{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pvc), existing)).To(Succeed())
+				g.Expect(existing.OwnerReferences).To(BeEmpty())
+			}).WithContext(ctx).WithTimeout(10 * time.Second).Should(Succeed())
+		}, specTimeOut)
+	})
+})
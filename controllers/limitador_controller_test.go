@@ -526,6 +526,27 @@ var _ = Describe("Limitador controller", func() {
 		}, specTimeOut)
 	})
 
+	Context("Redis CA bundle with more than one source", func() {
+		It("resource is rejected", func(ctx SpecContext) {
+			limitadorObj := limitadorWithRedisStorage(client.ObjectKey{Name: "redis", Namespace: testNamespace}, testNamespace)
+			limitadorObj.Spec.Storage.Redis.TLS = &limitadorv1alpha1.RedisTLS{
+				CABundle: &limitadorv1alpha1.CABundleSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"},
+						Key:                  "ca.crt",
+					},
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"},
+						Key:                  "ca.crt",
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, limitadorObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exactly one of configMapKeyRef or secretKeyRef must be set"))
+		}, specTimeOut)
+	})
+
 	Context("Deploying limitador object with redis storage", func() {
 		var redisSecret *corev1.Secret
 
@@ -579,6 +600,37 @@ var _ = Describe("Limitador controller", func() {
 				),
 			)
 		}, specTimeOut)
+
+		It("rolls out new pods when the secret URL changes", func(ctx SpecContext) {
+			limitadorObj := limitadorWithRedisStorage(client.ObjectKeyFromObject(redisSecret), testNamespace)
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+			Eventually(testLimitadorIsReady(ctx, limitadorObj)).WithContext(ctx).Should(Succeed())
+
+			deploymentKey := types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      limitador.DeploymentName(limitadorObj),
+			}
+			deploymentObj := appsv1.Deployment{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, deploymentKey, &deploymentObj)).To(Succeed())
+				g.Expect(deploymentObj.Spec.Template.Annotations).To(
+					HaveKey(limitadorv1alpha1.PodAnnotationStorageConfigHash))
+			}).WithContext(ctx).Should(Succeed())
+			initialHash := deploymentObj.Spec.Template.Annotations[limitadorv1alpha1.PodAnnotationStorageConfigHash]
+
+			Eventually(func(g Gomega) {
+				secret := &corev1.Secret{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(redisSecret), secret)).To(Succeed())
+				secret.Data["URL"] = []byte(fmt.Sprintf("redis://%s.%s.svc:6379", redisService(testNamespace).Name, testNamespace))
+				g.Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, deploymentKey, &deploymentObj)).To(Succeed())
+				g.Expect(deploymentObj.Spec.Template.Annotations[limitadorv1alpha1.PodAnnotationStorageConfigHash]).ToNot(
+					Equal(initialHash))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
 	})
 
 	Context("Deploying limitador object with redis cached storage", func() {
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/codes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+	"github.com/kuadrant/limitador-operator/pkg/observability"
+)
+
+// ensureFinalizer adds the finalizer releasing the retained storage to the Limitador object,
+// when its storage retains persistent volume claims
+func (r *LimitadorReconciler) ensureFinalizer(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	if len(limitador.RetainedPVCNames(limitadorObj)) == 0 {
+		return nil
+	}
+
+	if !controllerutil.AddFinalizer(limitadorObj, limitadorv1alpha1.LimitadorFinalizer) {
+		return nil
+	}
+
+	return r.UpdateResource(ctx, limitadorObj)
+}
+
+// removeFinalizer removes the finalizer from the Limitador object once its storage retains no persistent volume claim.
+// It is called once the spec is reconciled, so the claims no longer used have been released already.
+func (r *LimitadorReconciler) removeFinalizer(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	if len(limitador.RetainedPVCNames(limitadorObj)) > 0 {
+		return nil
+	}
+
+	if !controllerutil.RemoveFinalizer(limitadorObj, limitadorv1alpha1.LimitadorFinalizer) {
+		return nil
+	}
+
+	return r.UpdateResource(ctx, limitadorObj)
+}
+
+// finalizeLimitador releases the persistent volume claims retained by the storage retain policies
+// from the Limitador object being deleted, so the garbage collector does not delete them, and removes the finalizer.
+// The other owned objects are deleted by the garbage collector.
+func (r *LimitadorReconciler) finalizeLimitador(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	if !controllerutil.ContainsFinalizer(limitadorObj, limitadorv1alpha1.LimitadorFinalizer) {
+		return nil
+	}
+
+	ctx, span := r.Tracer().StartResourceSpan(ctx, "Limitador", limitadorObj.Namespace, limitadorObj.Name)
+	defer span.End()
+
+	for _, pvcName := range limitador.RetainedPVCNames(limitadorObj) {
+		if err := r.releasePVC(ctx, limitadorObj, pvcName); err != nil {
+			observability.RecordError(span, err, "failed to release PVC")
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(limitadorObj, limitadorv1alpha1.LimitadorFinalizer)
+	if err := r.UpdateResource(ctx, limitadorObj); err != nil {
+		observability.RecordError(span, err, "failed to remove finalizer")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// releaseUnusedPVC releases, instead of deleting, the persistent volume claim no longer used by the storage
+// when it was created with the retain policy. It returns whether the claim was released.
+func (r *LimitadorReconciler) releaseUnusedPVC(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, desired *corev1.PersistentVolumeClaim) (bool, error) {
+	if !helpers.IsObjectTaggedToDelete(desired) {
+		return false, nil
+	}
+
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := r.GetResource(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if existing.Annotations[helpers.RetainPolicyAnnotation] != string(limitadorv1alpha1.RetainPolicyRetain) {
+		return false, nil
+	}
+
+	return true, r.releasePVC(ctx, limitadorObj, existing.Name)
+}
+
+// releasePVC removes the owner reference of the Limitador object from the persistent volume claim,
+// and annotates it to be adopted by a Limitador object of the same name
+func (r *LimitadorReconciler) releasePVC(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, pvcName string) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.GetResource(ctx, client.ObjectKey{Namespace: limitadorObj.Namespace, Name: pvcName}, pvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	ownerReferences := make([]metav1.OwnerReference, 0, len(pvc.OwnerReferences))
+	for _, ownerReference := range pvc.OwnerReferences {
+		if ownerReference.UID != limitadorObj.UID {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	if len(ownerReferences) == len(pvc.OwnerReferences) {
+		return nil
+	}
+
+	pvc.OwnerReferences = ownerReferences
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[helpers.ReleasedByAnnotation] = limitadorObj.Name
+	err = r.UpdateResource(ctx, pvc)
+	logger.V(1).Info("release pvc", "name", pvcName, "error", err)
+	return err
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+// reconcileLimitsRollout applies the limits ConfigMap. With spec.limitsRollout, a change of the limits
+// is loaded by the canary pods first, and applied to the limits ConfigMap once the canary pods baked it.
+// The first limits are not staged, there is no previous limits config to keep.
+func (r *LimitadorReconciler) reconcileLimitsRollout(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, limitsConfigMap *corev1.ConfigMap) (ctrl.Result, error) {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	canaryConfigMap, err := r.limitsCanaryConfigMap(ctx, limitadorObj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	canaryDeployment, err := r.limitsCanaryDeployment(ctx, limitadorObj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	currentConfigMap, err := r.limitsConfigMap(ctx, limitadorObj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var rollout *limitadorv1alpha1.LimitsRolloutStatus
+	var requeueAfter time.Duration
+	if limitadorObj.Spec.LimitsRollout != nil && currentConfigMap != nil &&
+		currentConfigMap.Data[limitador.LimitadorConfigFileName] != limitsConfigMap.Data[limitador.LimitadorConfigFileName] {
+		currentRollout, err := limitador.LimitsRolloutStatusFromConfigMap(canaryConfigMap)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		rollout, requeueAfter = limitador.NextLimitsRolloutStatus(limitadorObj, limitsConfigMap, currentRollout, canaryDeployment, time.Now())
+		if rollout != nil && rollout.Phase == limitadorv1alpha1.LimitsRolloutPhaseRolledBack &&
+			(currentRollout == nil || currentRollout.Phase != limitadorv1alpha1.LimitsRolloutPhaseRolledBack) {
+			logger.Info("limits rollout rolled back", "configHash", rollout.ConfigHash, "reason", rollout.Message)
+		}
+	}
+
+	if rollout == nil {
+		err = r.ReconcileConfigMap(ctx, limitsConfigMap)
+		logger.V(1).Info("reconcile limits ConfigMap", "error", err)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	desiredCanaryConfigMap, err := limitador.LimitsCanaryConfigMap(limitadorObj, limitsConfigMap, rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileLimitsCanaryObject(ctx, limitadorObj, desiredCanaryConfigMap, canaryConfigMap != nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	deploymentOptions := limitador.DeploymentOptions{}
+	if rollout != nil && rollout.Phase != limitadorv1alpha1.LimitsRolloutPhaseRolledBack {
+		if deploymentOptions, err = r.getDeploymentOptions(ctx, limitadorObj); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	desiredCanaryDeployment := limitador.LimitsCanaryDeployment(limitadorObj, deploymentOptions, rollout)
+	if err := r.reconcileLimitsCanaryObject(ctx, limitadorObj, desiredCanaryDeployment, canaryDeployment != nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileLimitsCanaryTraffic(ctx, limitadorObj, rollout); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileLimitsCanaryTraffic routes a share of the Service traffic to the canary pods, and restricts
+// their traffic as the one of the limitador pods. The canary pods are not selected by the Service nor by
+// the NetworkPolicy, their EndpointSlices and NetworkPolicy are managed by the operator.
+func (r *LimitadorReconciler) reconcileLimitsCanaryTraffic(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, rollout *limitadorv1alpha1.LimitsRolloutStatus) error {
+	podList := &corev1.PodList{}
+	options := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(limitador.LimitsCanarySelectorLabels(limitadorObj)),
+		Namespace:     limitadorObj.Namespace,
+	}
+	if err := r.Client().List(ctx, podList, options); err != nil {
+		return err
+	}
+
+	for _, desired := range limitador.LimitsCanaryEndpointSlices(limitadorObj, podList.Items, rollout) {
+		existing := &discoveryv1.EndpointSlice{}
+		exists, err := r.limitsCanaryObjectExists(ctx, client.ObjectKeyFromObject(desired), existing)
+		if err != nil {
+			return err
+		}
+		if err := r.reconcileLimitsCanaryObject(ctx, limitadorObj, desired, exists); err != nil {
+			return err
+		}
+	}
+
+	var egressRules []networkingv1.NetworkPolicyEgressRule
+	networkPolicyEnabled := limitadorObj.Spec.NetworkPolicy != nil
+	if networkPolicyEnabled && rollout != nil && limitadorObj.Spec.NetworkPolicy.Egress {
+		// The storage secret and the Redis services are not in the label filtered cache
+		var err error
+		if egressRules, err = limitador.NetworkPolicyEgressRules(ctx, r.APIClientReader(), limitadorObj); err != nil {
+			return err
+		}
+	}
+	desiredNetworkPolicy := limitador.LimitsCanaryNetworkPolicy(limitadorObj, egressRules, rollout)
+	exists, err := r.limitsCanaryObjectExists(ctx, client.ObjectKeyFromObject(desiredNetworkPolicy), &networkingv1.NetworkPolicy{})
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileLimitsCanaryObject(ctx, limitadorObj, desiredNetworkPolicy, exists)
+}
+
+// limitsCanaryObjectExists reads a canary object from the cache
+func (r *LimitadorReconciler) limitsCanaryObjectExists(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
+	if err := r.Client().Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reconcileLimitsCanaryObject applies a canary object, or deletes it when tagged to be deleted.
+// The canary objects only exist during a rollout, a missing one is not deleted on every reconciliation.
+func (r *LimitadorReconciler) reconcileLimitsCanaryObject(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, obj client.Object, exists bool) error {
+	if helpers.IsObjectTaggedToDelete(obj) && !exists {
+		return nil
+	}
+
+	if err := r.SetOwnerReference(limitadorObj, obj); err != nil {
+		return err
+	}
+
+	return r.ReconcileResource(ctx, obj)
+}
+
+// limitsCanaryConfigMap returns nil while no limits rollout is in progress
+func (r *LimitadorReconciler) limitsCanaryConfigMap(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Namespace: limitadorObj.Namespace, Name: limitador.LimitsCanaryConfigMapName(limitadorObj)}
+	if err := r.Client().Get(ctx, cmKey, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+// limitsCanaryDeployment returns nil while no limits rollout is in progress
+func (r *LimitadorReconciler) limitsCanaryDeployment(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: limitadorObj.Namespace, Name: limitador.LimitsCanaryDeploymentName(limitadorObj)}
+	if err := r.Client().Get(ctx, deploymentKey, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return deployment, nil
+}
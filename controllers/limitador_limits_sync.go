@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+const (
+	// limitsSyncMaxRequests caps the requests of a limits check, a pod being queried once per limit namespace.
+	// At least one pod is queried.
+	limitsSyncMaxRequests = 30
+	// limitsSyncPodTimeout bounds the time spent querying a pod
+	limitsSyncPodTimeout = 5 * time.Second
+)
+
+// limitsSyncedCondition queries the limits loaded by the ready pods and compares them with the limits of the
+// limits ConfigMap. The pods queried are sampled when querying every ready pod exceeds limitsSyncMaxRequests.
+func (r *LimitadorReconciler) limitsSyncedCondition(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, limitsConfigMap *corev1.ConfigMap, pods []corev1.Pod) (*metav1.Condition, error) {
+	cond := &metav1.Condition{
+		Type:   limitadorv1alpha1.StatusConditionLimitsSynced,
+		Status: metav1.ConditionUnknown,
+	}
+
+	if limitsConfigMap == nil {
+		cond.Reason = "LimitsConfigMapNotFound"
+		cond.Message = "Limits ConfigMap not found"
+		return cond, nil
+	}
+
+	expected, err := limitador.ConfigMapLimits(limitsConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	expectedByNamespace := limitador.LimitsByNamespace(expected)
+	namespaces := make([]string, 0, len(expectedByNamespace))
+	for namespace := range expectedByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	readyPods := queryablePods(pods)
+	if len(readyPods) == 0 {
+		cond.Reason = "NoPodsReady"
+		cond.Message = "No limitador pod ready"
+		return cond, nil
+	}
+
+	checkedPods := readyPods
+	if maxPods := max(1, limitsSyncMaxRequests/max(1, len(namespaces))); len(readyPods) > maxPods {
+		// A random sample, every pod gets checked over the reconciliations
+		checkedPods = slices.Clone(readyPods)
+		rand.Shuffle(len(checkedPods), func(i, j int) { checkedPods[i], checkedPods[j] = checkedPods[j], checkedPods[i] })
+		checkedPods = checkedPods[:maxPods]
+	}
+
+	issues := []string{}
+	for _, pod := range checkedPods {
+		issues = append(issues, r.podLimitsIssues(ctx, limitadorObj, pod, namespaces, expectedByNamespace)...)
+	}
+
+	if len(issues) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "LimitsNotSynced"
+		cond.Message = strings.Join(issues, "; ")
+		return cond, nil
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = "LimitsSynced"
+	cond.Message = fmt.Sprintf("Limits loaded by %d pods", len(checkedPods))
+	if len(checkedPods) < len(readyPods) {
+		cond.Message = fmt.Sprintf("Limits loaded by the %d pods checked out of %d ready pods", len(checkedPods), len(readyPods))
+	}
+	return cond, nil
+}
+
+// podLimitsIssues compares the limits loaded by the pod with the expected ones, for every limit namespace.
+// The queries of the pod time out after limitsSyncPodTimeout.
+func (r *LimitadorReconciler) podLimitsIssues(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, pod *corev1.Pod, namespaces []string, expectedByNamespace map[string][]limitadorv1alpha1.RateLimit) []string {
+	ctx, cancel := context.WithTimeout(ctx, limitsSyncPodTimeout)
+	defer cancel()
+
+	issues := []string{}
+	endpoint := podEndpoint(limitadorObj, pod)
+	for _, namespace := range namespaces {
+		loaded, err := r.LimitsClient.GetLimits(ctx, endpoint, namespace)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("pod %s: %s", pod.Name, err))
+			if ctx.Err() != nil {
+				// The other namespaces would time out as well
+				break
+			}
+			continue
+		}
+
+		missing, unexpected := limitador.DiffLimits(expectedByNamespace[namespace], loaded)
+		if len(missing) > 0 || len(unexpected) > 0 {
+			issues = append(issues, fmt.Sprintf("pod %s: namespace %s: %d limits missing, %d unexpected limits",
+				pod.Name, namespace, len(missing), len(unexpected)))
+		}
+	}
+
+	return issues
+}
+
+// queryablePods returns the ready pods with an IP, not being deleted, whose HTTP API can be queried
+func queryablePods(pods []corev1.Pod) []*corev1.Pod {
+	result := make([]*corev1.Pod, 0, len(pods))
+	for idx := range pods {
+		pod := &pods[idx]
+		if pod.GetDeletionTimestamp() != nil || pod.Status.PodIP == "" || !helpers.IsPodReady(pod) {
+			continue
+		}
+		result = append(result, pod)
+	}
+	return result
+}
+
+// podEndpoint returns the endpoint of the HTTP API of the pod, e.g. http://10.244.0.7:8080
+func podEndpoint(limitadorObj *limitadorv1alpha1.Limitador, pod *corev1.Pod) string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(limitadorObj.HTTPPort()))))
+}
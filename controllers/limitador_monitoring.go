@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"go.opentelemetry.io/otel/codes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+	"github.com/kuadrant/limitador-operator/pkg/observability"
+)
+
+// reconcileMonitoring applies the Prometheus monitor requested in the spec and deletes the other kind.
+// Monitor kinds whose CRD is not installed are skipped.
+func (r *LimitadorReconciler) reconcileMonitoring(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) error {
+	ctx, span := r.Tracer().StartResourceSpan(ctx, "Monitor", limitadorObj.Namespace, limitador.MonitorName(limitadorObj))
+	defer span.End()
+
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	monitors := []client.Object{
+		limitador.ServiceMonitor(limitadorObj),
+		limitador.PodMonitor(limitadorObj),
+	}
+
+	for _, monitor := range monitors {
+		kind := monitor.GetObjectKind().GroupVersionKind().Kind
+		installed, err := r.monitorKindInstalled(kind)
+		if err != nil {
+			observability.RecordError(span, err, "failed to look up monitor kind")
+			return err
+		}
+		if !installed {
+			logger.V(1).Info("reconcile monitoring", "kind", kind, "status", "CRD not installed, skipping")
+			continue
+		}
+
+		if err := r.SetOwnerReference(limitadorObj, monitor); err != nil {
+			observability.RecordError(span, err, "failed to set owner reference")
+			return err
+		}
+
+		err = r.ReconcileResource(ctx, monitor)
+		logger.V(1).Info("reconcile monitoring", "kind", kind, "error", err)
+		if err != nil {
+			observability.RecordError(span, err, "failed to reconcile monitor")
+			return err
+		}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// monitorKindInstalled tells whether the monitoring.coreos.com CRD of the kind is installed in the cluster
+func (r *LimitadorReconciler) monitorKindInstalled(kind string) (bool, error) {
+	groupKind := schema.GroupKind{Group: monitoringv1.SchemeGroupVersion.Group, Kind: kind}
+	_, err := r.Client().RESTMapper().RESTMapping(groupKind, monitoringv1.SchemeGroupVersion.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// monitoringCondition reports whether the Prometheus monitor requested in the spec could be reconciled.
+// Returns nil when monitoring is not enabled.
+func (r *LimitadorReconciler) monitoringCondition(limitadorObj *limitadorv1alpha1.Limitador) (*metav1.Condition, error) {
+	monitorKind := limitadorObj.MonitorKind()
+	if monitorKind == nil {
+		return nil, nil
+	}
+
+	installed, err := r.monitorKindInstalled(string(*monitorKind))
+	if err != nil {
+		return nil, err
+	}
+
+	if !installed {
+		return &metav1.Condition{
+			Type:    limitadorv1alpha1.StatusConditionMonitoring,
+			Status:  metav1.ConditionFalse,
+			Reason:  "MonitoringCRDNotFound",
+			Message: fmt.Sprintf("%s CRD (%s) is not installed", *monitorKind, monitoringv1.SchemeGroupVersion),
+		}, nil
+	}
+
+	return &metav1.Condition{
+		Type:    limitadorv1alpha1.StatusConditionMonitoring,
+		Status:  metav1.ConditionTrue,
+		Reason:  "MonitorReconciled",
+		Message: fmt.Sprintf("%s %s reconciled", *monitorKind, limitador.MonitorName(limitadorObj)),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+	"github.com/kuadrant/limitador-operator/pkg/observability"
+)
+
+// shadowHitsFunc returns the function reading the hits of the shadow limits from the limitador pods on scrape.
+// Nil without limits client.
+func (r *LimitadorReconciler) shadowHitsFunc(limitadorObj *limitadorv1alpha1.Limitador) observability.ShadowHitsFunc {
+	if r.LimitsClient == nil {
+		return nil
+	}
+
+	limitadorObj = limitadorObj.DeepCopy()
+	return func(ctx context.Context) []observability.ShadowLimitHits {
+		logger := r.Logger().WithValues("limitador", client.ObjectKeyFromObject(limitadorObj))
+		hits, err := r.shadowHits(ctx, limitadorObj)
+		if err != nil {
+			logger.V(1).Info("failed to read the shadow limits hits", "error", err)
+		}
+
+		result := make([]observability.ShadowLimitHits, 0, len(hits))
+		for _, limitHits := range hits {
+			result = append(result, observability.ShadowLimitHits{
+				LimitNamespace: limitHits.Namespace,
+				LimitName:      limitHits.Name,
+				Hits:           limitHits.Hits,
+				MaxCounterHits: limitHits.MaxCounterHits,
+			})
+		}
+		return result
+	}
+}
+
+// shadowHits reads the counters of the shadow limits from the ready limitador pods. The counters of a storage
+// shared by the pods are read from a single pod, the ones of the other storages are read from every pod.
+// No hits are returned when a pod cannot be queried, rather than partial hits.
+func (r *LimitadorReconciler) shadowHits(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) ([]limitador.ShadowLimitHits, error) {
+	limitsConfigMap, err := r.limitsConfigMap(ctx, limitadorObj)
+	if err != nil || limitsConfigMap == nil {
+		return nil, err
+	}
+
+	limits, err := limitador.ConfigMapLimits(limitsConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := limitador.ShadowLimitNamespaces(limits)
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	pods, err := r.limitadorPods(ctx, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+	readyPods := queryablePods(pods)
+	if sharedStorage(limitadorObj) && len(readyPods) > 1 {
+		readyPods = []*corev1.Pod{readyPods[0]}
+	}
+
+	hits := limitador.ShadowHits{}
+	for _, pod := range readyPods {
+		for _, namespace := range namespaces {
+			counters, err := r.LimitsClient.GetCounters(ctx, podEndpoint(limitadorObj, pod), namespace)
+			if err != nil {
+				return nil, err
+			}
+			hits.Add(counters)
+		}
+	}
+
+	return hits.List(), nil
+}
+
+// sharedStorage tells whether the counters are shared by the limitador pods
+func sharedStorage(limitadorObj *limitadorv1alpha1.Limitador) bool {
+	return limitadorObj.Spec.Storage != nil && (limitadorObj.Spec.Storage.Redis != nil || limitadorObj.Spec.Storage.RedisCached != nil)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -10,6 +11,8 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +24,12 @@ import (
 	"github.com/kuadrant/limitador-operator/pkg/observability"
 )
 
-func (r *LimitadorReconciler) reconcileStatus(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, specErr error) (ctrl.Result, error) {
+const (
+	limitsAcknowledgedRequeueDelay    = 5 * time.Second
+	limitsAcknowledgedMaxRequeueDelay = 5 * time.Minute
+)
+
+func (r *LimitadorReconciler) reconcileStatus(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, sourcesLimits *limitador.SourcesLimits, specErr error) (ctrl.Result, error) {
 	ctx, span := r.Tracer().StartReconcileStatusSpan(ctx)
 	defer span.End()
 
@@ -30,9 +38,16 @@ func (r *LimitadorReconciler) reconcileStatus(ctx context.Context, limitadorObj
 		return reconcile.Result{}, err
 	}
 
-	newStatus, err := r.calculateStatus(ctx, limitadorObj, specErr)
+	newStatus, err := r.calculateStatus(ctx, limitadorObj, sourcesLimits, specErr)
 	if err != nil {
 		observability.RecordError(span, err, "failed to calculate status")
+		observability.RecordReconcileError(observability.PhaseStatus)
+		return reconcile.Result{}, err
+	}
+
+	if err := r.recordLimitadorMetrics(ctx, limitadorObj, newStatus); err != nil {
+		observability.RecordError(span, err, "failed to record metrics")
+		observability.RecordReconcileError(observability.PhaseStatus)
 		return reconcile.Result{}, err
 	}
 
@@ -43,7 +58,7 @@ func (r *LimitadorReconciler) reconcileStatus(ctx context.Context, limitadorObj
 		// Steady state
 		logger.V(1).Info("Status was not updated")
 		observability.RecordStatusCompleted(span)
-		return reconcile.Result{}, nil
+		return limitsAcknowledgedResult(newStatus), nil
 	}
 
 	logger.V(1).Info("Updating Status", "sequence no:", fmt.Sprintf("sequence No: %v->%v", limitadorObj.Status.ObservedGeneration, newStatus.ObservedGeneration))
@@ -63,19 +78,51 @@ func (r *LimitadorReconciler) reconcileStatus(ctx context.Context, limitadorObj
 	updateErr := r.UpdateResourceStatus(ctx, patch)
 	if updateErr != nil {
 		observability.RecordError(span, updateErr, "failed to update status")
+		observability.RecordReconcileError(observability.PhaseStatus)
 		return reconcile.Result{}, fmt.Errorf("failed to update status: %w", updateErr)
 	}
 	observability.RecordStatusCompleted(span)
-	return ctrl.Result{}, nil
+	return limitsAcknowledgedResult(newStatus), nil
+}
+
+// limitsAcknowledgedResult requeues until every pod acknowledges the limits ConfigMap resource version
+// and loads the limits, as neither pod annotation updates nor limits reloads trigger any reconciliation
+func limitsAcknowledgedResult(status *limitadorv1alpha1.LimitadorStatus) ctrl.Result {
+	if status.Limits != nil && status.Limits.AcknowledgedResourceVersion != status.Limits.ConfigMapResourceVersion {
+		return ctrl.Result{RequeueAfter: limitsRequeueDelay(status.Limits.LastUpdateTime)}
+	}
+
+	if cond := meta.FindStatusCondition(status.Conditions, limitadorv1alpha1.StatusConditionLimitsSynced); cond != nil && cond.Status == metav1.ConditionFalse {
+		return ctrl.Result{RequeueAfter: limitsRequeueDelay(&cond.LastTransitionTime)}
+	}
+
+	return ctrl.Result{}
 }
 
-func (r *LimitadorReconciler) calculateStatus(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, specErr error) (*limitadorv1alpha1.LimitadorStatus, error) {
+// limitsRequeueDelay backs off as the wait goes on: the delay is the time waited since the given time,
+// within limitsAcknowledgedRequeueDelay and limitsAcknowledgedMaxRequeueDelay
+func limitsRequeueDelay(since *metav1.Time) time.Duration {
+	if since == nil || since.IsZero() {
+		return limitsAcknowledgedRequeueDelay
+	}
+
+	return min(max(time.Since(since.Time), limitsAcknowledgedRequeueDelay), limitsAcknowledgedMaxRequeueDelay)
+}
+
+// calculateStatus reports the limits of the sources loaded by the spec reconciliation.
+// The limits validity is not updated when the spec reconciliation failed before loading the sources.
+func (r *LimitadorReconciler) calculateStatus(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, sourcesLimits *limitador.SourcesLimits, specErr error) (*limitadorv1alpha1.LimitadorStatus, error) {
+	serviceHost, err := r.serviceHost(ctx, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+
 	newStatus := &limitadorv1alpha1.LimitadorStatus{
 		ObservedGeneration: limitadorObj.Generation,
 		// Copy initial conditions. Otherwise, status will always be updated
 		Conditions: helpers.DeepCopyConditions(limitadorObj.Status.Conditions),
 		Service: &limitadorv1alpha1.LimitadorService{
-			Host: buildServiceHost(limitadorObj),
+			Host: serviceHost,
 			Ports: limitadorv1alpha1.Ports{
 				HTTP: limitadorObj.HTTPPort(),
 				GRPC: limitadorObj.GRPCPort(),
@@ -83,6 +130,33 @@ func (r *LimitadorReconciler) calculateStatus(ctx context.Context, limitadorObj
 		},
 	}
 
+	limitsConfigMap, err := r.limitsConfigMap(ctx, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := r.limitadorPods(ctx, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if limitsConfigMap != nil {
+		newStatus.Limits, err = limitador.LimitsStatus(limitsConfigMap, pods)
+		if err != nil {
+			return nil, err
+		}
+		newStatus.Limits.LastUpdateTime = limitsLastUpdateTime(limitadorObj.Status.Limits, newStatus.Limits)
+	}
+
+	canaryConfigMap, err := r.limitsCanaryConfigMap(ctx, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+	newStatus.LimitsRollout, err = limitador.LimitsRolloutStatusFromConfigMap(canaryConfigMap)
+	if err != nil {
+		return nil, err
+	}
+
 	availableCond, err := r.readyCondition(ctx, limitadorObj, specErr)
 	if err != nil {
 		return nil, err
@@ -90,9 +164,83 @@ func (r *LimitadorReconciler) calculateStatus(ctx context.Context, limitadorObj
 
 	meta.SetStatusCondition(&newStatus.Conditions, *availableCond)
 
+	if sourcesLimits != nil {
+		meta.SetStatusCondition(&newStatus.Conditions, limitsValidCondition(limitadorObj, sourcesLimits.Errors))
+	}
+
+	monitoringCond, err := r.monitoringCondition(limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+	if monitoringCond != nil {
+		meta.SetStatusCondition(&newStatus.Conditions, *monitoringCond)
+	} else {
+		meta.RemoveStatusCondition(&newStatus.Conditions, limitadorv1alpha1.StatusConditionMonitoring)
+	}
+
+	storageConfigDriftCond, err := r.storageConfigDriftCondition(ctx, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+	if storageConfigDriftCond != nil {
+		meta.SetStatusCondition(&newStatus.Conditions, *storageConfigDriftCond)
+	} else {
+		meta.RemoveStatusCondition(&newStatus.Conditions, limitadorv1alpha1.StatusConditionStorageConfigDrift)
+	}
+
+	if r.LimitsClient != nil {
+		limitsSyncedCond, err := r.limitsSyncedCondition(ctx, limitadorObj, limitsConfigMap, pods)
+		if err != nil {
+			return nil, err
+		}
+		meta.SetStatusCondition(&newStatus.Conditions, *limitsSyncedCond)
+	}
+
 	return newStatus, nil
 }
 
+// limitsLastUpdateTime keeps the last update time of the limits while the rendered limits config does not change
+func limitsLastUpdateTime(current, updated *limitadorv1alpha1.LimitsStatus) *metav1.Time {
+	if current != nil && current.LastUpdateTime != nil && current.ConfigHash == updated.ConfigHash {
+		return current.LastUpdateTime
+	}
+
+	now := metav1.Now()
+	return &now
+}
+
+// recordLimitadorMetrics records the state of the limitador instance reported by the operator metrics
+func (r *LimitadorReconciler) recordLimitadorMetrics(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, status *limitadorv1alpha1.LimitadorStatus) error {
+	state := observability.LimitadorMetrics{
+		StorageType: storageType(limitadorObj),
+		Ready:       meta.IsStatusConditionTrue(status.Conditions, limitadorv1alpha1.StatusConditionReady),
+		ShadowHits:  r.shadowHitsFunc(limitadorObj),
+	}
+
+	if status.Limits != nil {
+		state.Limits = status.Limits.Total
+		if status.Limits.LastUpdateTime != nil {
+			state.LimitsLastUpdate = status.Limits.LastUpdateTime.Time
+		}
+
+		pods, err := r.limitadorPods(ctx, limitadorObj)
+		if err != nil {
+			return err
+		}
+		for idx := range pods {
+			if pods[idx].GetDeletionTimestamp() != nil {
+				continue
+			}
+			if pods[idx].GetAnnotations()[limitadorv1alpha1.PodAnnotationConfigMapResourceVersion] != status.Limits.ConfigMapResourceVersion {
+				state.LaggingPods++
+			}
+		}
+	}
+
+	observability.SetLimitadorMetrics(client.ObjectKeyFromObject(limitadorObj), state)
+	return nil
+}
+
 func (r *LimitadorReconciler) readyCondition(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, specErr error) (*metav1.Condition, error) {
 	cond := &metav1.Condition{
 		Type:    limitadorv1alpha1.StatusConditionReady,
@@ -122,6 +270,52 @@ func (r *LimitadorReconciler) readyCondition(ctx context.Context, limitadorObj *
 	return cond, nil
 }
 
+// limitsConfigMap returns nil while the limits ConfigMap does not exist
+func (r *LimitadorReconciler) limitsConfigMap(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Namespace: limitadorObj.Namespace, Name: limitador.LimitsConfigMapName(limitadorObj)}
+	if err := r.Client().Get(ctx, cmKey, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+func (r *LimitadorReconciler) limitadorPods(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	options := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(limitador.SelectorLabels(limitadorObj)),
+		Namespace:     limitadorObj.Namespace,
+	}
+	if err := r.Client().List(ctx, podList, options); err != nil {
+		return nil, err
+	}
+
+	return podList.Items, nil
+}
+
+// limitsValidCondition reports the errors of the inline limits and of the spec.limitsFrom sources
+func limitsValidCondition(limitadorObj *limitadorv1alpha1.Limitador, sourcesErrs field.ErrorList) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    limitadorv1alpha1.StatusConditionLimitsValid,
+		Status:  metav1.ConditionTrue,
+		Reason:  "LimitsValid",
+		Message: "Limits are valid",
+	}
+
+	errs := limitadorObj.ValidateLimits()
+	if errs = append(errs, sourcesErrs...); len(errs) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "InvalidLimits"
+		cond.Message = errs.ToAggregate().Error()
+	}
+
+	return cond
+}
+
 func (r *LimitadorReconciler) checkLimitadorAvailable(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (*string, error) {
 	deployment := &appsv1.Deployment{}
 	dKey := client.ObjectKey{ // Its deployment is built after the same name and namespace
@@ -161,6 +355,34 @@ func (r *LimitadorReconciler) checkLimitadorAvailable(ctx context.Context, limit
 	return nil, nil
 }
 
+// serviceHost returns the ingress address of the load balancer for LoadBalancer services,
+// once provisioned, and the cluster DNS name of the service otherwise
+func (r *LimitadorReconciler) serviceHost(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (string, error) {
+	if serviceType, _ := limitadorObj.ServiceType(); serviceType != corev1.ServiceTypeLoadBalancer {
+		return buildServiceHost(limitadorObj), nil
+	}
+
+	service := &corev1.Service{}
+	serviceKey := client.ObjectKey{Namespace: limitadorObj.Namespace, Name: limitador.ServiceName(limitadorObj)}
+	if err := r.Client().Get(ctx, serviceKey, service); err != nil {
+		if apierrors.IsNotFound(err) {
+			return buildServiceHost(limitadorObj), nil
+		}
+		return "", err
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname, nil
+		}
+		if ingress.IP != "" {
+			return ingress.IP, nil
+		}
+	}
+
+	return buildServiceHost(limitadorObj), nil
+}
+
 func buildServiceHost(limitadorObj *limitadorv1alpha1.Limitador) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local", limitador.ServiceName(limitadorObj), limitadorObj.Namespace)
 }
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+// reconcileStoragePVC reconciles the persistent volume claim of the storage, expanding it when requested.
+// The claim no longer used by the storage is released when retained, deleted otherwise.
+func (r *LimitadorReconciler) reconcileStoragePVC(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador, desired *corev1.PersistentVolumeClaim) error {
+	released, err := r.releaseUnusedPVC(ctx, limitadorObj, desired)
+	if err != nil || released {
+		return err
+	}
+
+	if err := r.ReconcilePersistentVolumeClaim(ctx, desired); err != nil {
+		return err
+	}
+
+	return r.reconcilePVCExpansion(ctx, desired)
+}
+
+// reconcilePVCExpansion increases the storage request of the existing persistent volume claim
+// when requested in the spec and allowed by its storage class
+func (r *LimitadorReconciler) reconcilePVCExpansion(ctx context.Context, desired *corev1.PersistentVolumeClaim) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if helpers.IsObjectTaggedToDelete(desired) {
+		return nil
+	}
+
+	existing, expand, _, err := r.pvcDrift(ctx, desired)
+	if err != nil || existing == nil || !expand {
+		return err
+	}
+
+	existing.Spec.Resources.Requests[corev1.ResourceStorage] = desired.Spec.Resources.Requests[corev1.ResourceStorage]
+	err = r.UpdateResource(ctx, existing)
+	logger.V(1).Info("expand pvc", "name", existing.Name, "error", err)
+	return err
+}
+
+// pvcDrift compares the desired persistent volume claim with the existing one, if any. It returns whether
+// the existing claim can be expanded, and the changes that cannot be applied to it.
+func (r *LimitadorReconciler) pvcDrift(ctx context.Context, desired *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, bool, []string, error) {
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := r.Client().Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil, nil
+		}
+		return nil, false, nil, err
+	}
+
+	expand, drift := limitador.PVCDrift(desired, existing)
+	if !expand {
+		return existing, false, drift, nil
+	}
+
+	allowed, err := r.volumeExpansionAllowed(ctx, existing.Spec.StorageClassName)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if !allowed {
+		drift = append(drift, fmt.Sprintf("storage class %q does not allow volume expansion",
+			ptr.Deref(existing.Spec.StorageClassName, "")))
+	}
+
+	return existing, allowed, drift, nil
+}
+
+// volumeExpansionAllowed tells whether the storage class allows volume expansion.
+// Storage classes are not cached, hence read from the API server.
+func (r *LimitadorReconciler) volumeExpansionAllowed(ctx context.Context, storageClassName *string) (bool, error) {
+	if ptr.Deref(storageClassName, "") == "" {
+		return false, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if err := r.APIClientReader().Get(ctx, client.ObjectKey{Name: *storageClassName}, storageClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return ptr.Deref(storageClass.AllowVolumeExpansion, false), nil
+}
+
+// storageConfigDriftCondition reports the changes of the persistent volume claims requested in the spec
+// that cannot be applied. It returns nil when the storage has no persistent volume claim.
+func (r *LimitadorReconciler) storageConfigDriftCondition(ctx context.Context, limitadorObj *limitadorv1alpha1.Limitador) (*metav1.Condition, error) {
+	var desiredPVCs []*corev1.PersistentVolumeClaim
+	for _, pvc := range []*corev1.PersistentVolumeClaim{limitador.PVC(limitadorObj), limitador.ManagedRedisPVC(limitadorObj)} {
+		if !helpers.IsObjectTaggedToDelete(pvc) {
+			desiredPVCs = append(desiredPVCs, pvc)
+		}
+	}
+
+	if len(desiredPVCs) == 0 {
+		return nil, nil
+	}
+
+	var messages []string
+	for _, desired := range desiredPVCs {
+		_, _, drift, err := r.pvcDrift(ctx, desired)
+		if err != nil {
+			return nil, err
+		}
+		if len(drift) > 0 {
+			messages = append(messages, fmt.Sprintf("pvc %s: %s", desired.Name, strings.Join(drift, ", ")))
+		}
+	}
+
+	if len(messages) > 0 {
+		return &metav1.Condition{
+			Type:    limitadorv1alpha1.StatusConditionStorageConfigDrift,
+			Status:  metav1.ConditionTrue,
+			Reason:  "PVCConfigDrift",
+			Message: strings.Join(messages, "; "),
+		}, nil
+	}
+
+	return &metav1.Condition{
+		Type:    limitadorv1alpha1.StatusConditionStorageConfigDrift,
+		Status:  metav1.ConditionFalse,
+		Reason:  "PVCConfigApplied",
+		Message: "Storage config is applied",
+	}, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/reconcilers"
+)
+
+// RateLimitDefinitionReconciler reconciles a RateLimitDefinition object.
+// The limits of the definitions are aggregated, and their status reported, by the Limitador reconciler.
+// This reconciler only reports the status of the definitions whose Limitador object does not exist.
+type RateLimitDefinitionReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+//+kubebuilder:rbac:groups=limitador.kuadrant.io,resources=ratelimitdefinitions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=limitador.kuadrant.io,resources=ratelimitdefinitions/status,verbs=get;update;patch
+
+func (r *RateLimitDefinitionReconciler) Reconcile(eventCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("ratelimitdefinition", req.NamespacedName)
+	ctx := logr.NewContext(eventCtx, logger)
+	logger.V(1).Info("Reconciling RateLimitDefinition")
+
+	definition := &limitadorv1alpha1.RateLimitDefinition{}
+	if err := r.Client().Get(ctx, req.NamespacedName, definition); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("no object found")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "Failed to get RateLimitDefinition object.")
+		return ctrl.Result{}, err
+	}
+
+	if definition.GetDeletionTimestamp() != nil {
+		logger.Info("marked to be deleted")
+		return ctrl.Result{}, nil
+	}
+
+	limitadorObj := &limitadorv1alpha1.Limitador{}
+	err := r.Client().Get(ctx, definition.LimitadorKey(), limitadorObj)
+	if err == nil && limitadorObj.GetDeletionTimestamp() == nil {
+		// The status is reported by the Limitador reconciler
+		return ctrl.Result{}, nil
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to get Limitador object.")
+		return ctrl.Result{}, err
+	}
+
+	newStatus := calculateRateLimitDefinitionStatus(definition, nil, false)
+	if err := updateRateLimitDefinitionStatus(ctx, r.BaseReconciler, definition, newStatus); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("successfully reconciled")
+	return ctrl.Result{}, nil
+}
+
+// limitadorToRateLimitDefinitions maps a Limitador event to the RateLimitDefinition objects referencing it
+func (r *RateLimitDefinitionReconciler) limitadorToRateLimitDefinitions(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := r.Logger().WithValues("limitador", client.ObjectKeyFromObject(obj))
+
+	definitionList := &limitadorv1alpha1.RateLimitDefinitionList{}
+	if err := r.Client().List(ctx, definitionList); err != nil {
+		logger.Error(err, "failed to list ratelimitdefinition objects")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for idx := range definitionList.Items {
+		definition := &definitionList.Items[idx]
+		if definition.LimitadorKey() != client.ObjectKeyFromObject(obj) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(definition)})
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RateLimitDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&limitadorv1alpha1.RateLimitDefinition{}).
+		Watches(&limitadorv1alpha1.Limitador{}, handler.EnqueueRequestsFromMapFunc(r.limitadorToRateLimitDefinitions)).
+		Complete(r)
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+)
+
+var _ = Describe("RateLimitDefinition controller", func() {
+	const (
+		nodeTimeOut = NodeTimeout(time.Second * 30)
+		specTimeOut = SpecTimeout(time.Minute * 2)
+	)
+
+	var (
+		testNamespace string
+		teamNamespace string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		CreateNamespaceWithContext(ctx, &testNamespace)
+		CreateNamespaceWithContext(ctx, &teamNamespace)
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteNamespaceWithContext(ctx, &testNamespace)
+		DeleteNamespaceWithContext(ctx, &teamNamespace)
+	}, nodeTimeOut)
+
+	newDefinition := func(name string, limitadorObj *limitadorv1alpha1.Limitador, limits ...limitadorv1alpha1.RateLimit) *limitadorv1alpha1.RateLimitDefinition {
+		return &limitadorv1alpha1.RateLimitDefinition{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "RateLimitDefinition",
+				APIVersion: limitadorv1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: teamNamespace},
+			Spec: limitadorv1alpha1.RateLimitDefinitionSpec{
+				LimitadorRef: limitadorv1alpha1.LimitadorReference{
+					Name:      limitadorObj.Name,
+					Namespace: limitadorObj.Namespace,
+				},
+				Limits: limits,
+			},
+		}
+	}
+
+	configMapLimits := func(ctx SpecContext, g Gomega, limitadorObj *limitadorv1alpha1.Limitador) []limitadorv1alpha1.RateLimit {
+		cm := &v1.ConfigMap{}
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: limitadorObj.Namespace,
+			Name:      limitador.LimitsConfigMapName(limitadorObj),
+		}, cm)).To(Succeed())
+
+		var cmLimits []limitadorv1alpha1.RateLimit
+		g.Expect(yaml.Unmarshal([]byte(cm.Data[limitador.LimitadorConfigFileName]), &cmLimits)).To(Succeed())
+		return cmLimits
+	}
+
+	Context("Creating definitions selecting a Limitador object", func() {
+		var limitadorObj *limitadorv1alpha1.Limitador
+
+		inlineLimit := limitadorv1alpha1.RateLimit{
+			Conditions: []string{"req.method == 'GET'"},
+			MaxValue:   10,
+			Namespace:  "test-namespace",
+			Seconds:    60,
+			Variables:  []string{},
+			Name:       "inline",
+		}
+		definitionLimit := limitadorv1alpha1.RateLimit{
+			Conditions: []string{"req.method == 'POST'"},
+			MaxValue:   5,
+			Namespace:  "test-namespace",
+			Seconds:    60,
+			Variables:  []string{},
+			Name:       "team",
+		}
+
+		BeforeEach(func(ctx SpecContext) {
+			limitadorObj = basicLimitador(testNamespace)
+			limitadorObj.Spec.Limits = []limitadorv1alpha1.RateLimit{inlineLimit}
+			limitadorObj.Spec.AllowedRateLimitDefinitionNamespaces = []limitadorv1alpha1.AllowedRateLimitDefinitionNamespace{
+				{Namespace: teamNamespace, LimitNamespaces: []string{"test-namespace"}},
+			}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+		})
+
+		It("Should aggregate the limits into the limits configmap", func(ctx SpecContext) {
+			definition := newDefinition("team", limitadorObj, definitionLimit)
+			Expect(k8sClient.Create(ctx, definition)).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(configMapLimits(ctx, g, limitadorObj)).To(Equal([]limitadorv1alpha1.RateLimit{inlineLimit, definitionLimit}))
+			}).WithContext(ctx).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(definition), definition)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(definition.Status.Conditions, limitadorv1alpha1.StatusConditionAccepted)).To(BeTrue())
+				g.Expect(definition.Status.Limits).To(Equal([]limitadorv1alpha1.RateLimitStatus{{Name: "team", Accepted: true}}))
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, definition)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(configMapLimits(ctx, g, limitadorObj)).To(Equal([]limitadorv1alpha1.RateLimit{inlineLimit}))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+
+		It("Should not accept limits with conflicting names", func(ctx SpecContext) {
+			conflicting := definitionLimit
+			conflicting.Name = inlineLimit.Name
+			definition := newDefinition("conflict", limitadorObj, conflicting)
+			Expect(k8sClient.Create(ctx, definition)).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(definition), definition)).To(Succeed())
+				cond := meta.FindStatusCondition(definition.Status.Conditions, limitadorv1alpha1.StatusConditionAccepted)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Reason).To(Equal("LimitsNotAccepted"))
+				g.Expect(definition.Status.Limits).To(HaveLen(1))
+				g.Expect(definition.Status.Limits[0].Accepted).To(BeFalse())
+			}).WithContext(ctx).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(configMapLimits(ctx, g, limitadorObj)).To(Equal([]limitadorv1alpha1.RateLimit{inlineLimit}))
+			}).WithContext(ctx).WithTimeout(5 * time.Second).Should(Succeed())
+		}, specTimeOut)
+	})
+
+	Context("Creating a definition from a namespace not allowed by the Limitador object", func() {
+		It("Should not accept the limits", func(ctx SpecContext) {
+			limitadorObj := basicLimitador(testNamespace)
+			limitadorObj.Spec.Limits = []limitadorv1alpha1.RateLimit{}
+			Expect(k8sClient.Create(ctx, limitadorObj)).Should(Succeed())
+
+			definition := newDefinition("not-allowed", limitadorObj, limitadorv1alpha1.RateLimit{
+				Conditions: []string{},
+				MaxValue:   5,
+				Namespace:  "test-namespace",
+				Seconds:    60,
+				Variables:  []string{},
+			})
+			Expect(k8sClient.Create(ctx, definition)).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(definition), definition)).To(Succeed())
+				cond := meta.FindStatusCondition(definition.Status.Conditions, limitadorv1alpha1.StatusConditionAccepted)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(cond.Reason).To(Equal("NotAllowed"))
+			}).WithContext(ctx).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(configMapLimits(ctx, g, limitadorObj)).To(BeEmpty())
+			}).WithContext(ctx).WithTimeout(5 * time.Second).Should(Succeed())
+		}, specTimeOut)
+	})
+
+	Context("Creating a definition selecting a missing Limitador object", func() {
+		It("Should report the Limitador is not found", func(ctx SpecContext) {
+			definition := newDefinition("missing", basicLimitador(testNamespace), limitadorv1alpha1.RateLimit{
+				Conditions: []string{},
+				MaxValue:   5,
+				Namespace:  "test-namespace",
+				Seconds:    60,
+				Variables:  []string{},
+			})
+			Expect(k8sClient.Create(ctx, definition)).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(definition), definition)).To(Succeed())
+				cond := meta.FindStatusCondition(definition.Status.Conditions, limitadorv1alpha1.StatusConditionAccepted)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Reason).To(Equal("LimitadorNotFound"))
+			}).WithContext(ctx).Should(Succeed())
+		}, specTimeOut)
+	})
+})
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/reconcilers"
+)
+
+// calculateRateLimitDefinitionStatus builds the status of the definition out of the statuses of its limits.
+// A nil limitsStatus means the referenced Limitador object does not exist. notAllowed means the
+// referenced Limitador object does not allow the namespace of the definition.
+func calculateRateLimitDefinitionStatus(definition *limitadorv1alpha1.RateLimitDefinition, limitsStatus []limitadorv1alpha1.RateLimitStatus, notAllowed bool) *limitadorv1alpha1.RateLimitDefinitionStatus {
+	newStatus := &limitadorv1alpha1.RateLimitDefinitionStatus{
+		ObservedGeneration: definition.Generation,
+		// Copy initial conditions. Otherwise, status will always be updated
+		Conditions: helpers.DeepCopyConditions(definition.Status.Conditions),
+		Limits:     limitsStatus,
+	}
+
+	cond := metav1.Condition{
+		Type:    limitadorv1alpha1.StatusConditionAccepted,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Accepted",
+		Message: "All limits accepted",
+	}
+
+	if limitsStatus == nil {
+		newStatus.Limits = make([]limitadorv1alpha1.RateLimitStatus, 0, len(definition.Limits()))
+		for _, limit := range definition.Limits() {
+			newStatus.Limits = append(newStatus.Limits, limitadorv1alpha1.RateLimitStatus{
+				Name:     limit.Name,
+				Accepted: false,
+				Message:  "Limitador not found",
+			})
+		}
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "LimitadorNotFound"
+		cond.Message = fmt.Sprintf("Limitador %s not found", definition.LimitadorKey())
+	} else if notAllowed {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "NotAllowed"
+		cond.Message = fmt.Sprintf("namespace %s not allowed by Limitador %s", definition.Namespace, definition.LimitadorKey())
+	} else {
+		notAccepted := 0
+		for _, limitStatus := range limitsStatus {
+			if !limitStatus.Accepted {
+				notAccepted++
+			}
+		}
+		if notAccepted > 0 {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = "LimitsNotAccepted"
+			cond.Message = fmt.Sprintf("%d of %d limits not accepted", notAccepted, len(limitsStatus))
+		}
+	}
+
+	if len(newStatus.Limits) == 0 {
+		newStatus.Limits = nil
+	}
+
+	meta.SetStatusCondition(&newStatus.Conditions, cond)
+
+	return newStatus
+}
+
+// updateRateLimitDefinitionStatus updates the status of the definition, unless it is already up to date
+func updateRateLimitDefinitionStatus(ctx context.Context, r *reconcilers.BaseReconciler, definition *limitadorv1alpha1.RateLimitDefinition, newStatus *limitadorv1alpha1.RateLimitDefinitionStatus) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if definition.Status.Equals(newStatus, logger) {
+		logger.V(1).Info("RateLimitDefinition status was not updated", "ratelimitdefinition", definition.Name)
+		return nil
+	}
+
+	patch := &limitadorv1alpha1.RateLimitDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: limitadorv1alpha1.GroupVersion.String(),
+			Kind:       "RateLimitDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      definition.Name,
+			Namespace: definition.Namespace,
+		},
+		Status: *newStatus,
+	}
+
+	if err := r.UpdateResourceStatus(ctx, patch); err != nil {
+		return fmt.Errorf("failed to update RateLimitDefinition status: %w", err)
+	}
+
+	return nil
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -26,6 +28,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -37,6 +41,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient/fake"
 	"github.com/kuadrant/limitador-operator/pkg/log"
 	"github.com/kuadrant/limitador-operator/pkg/reconcilers"
 	//+kubebuilder:scaffold:imports
@@ -47,6 +54,7 @@ import (
 
 var k8sClient client.Client
 var testEnv *envtest.Environment
+var fakeLimitador *fake.Server
 
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -80,6 +88,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	Expect(cfg).NotTo(BeNil())
 
 	Expect(limitadorv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(monitoringv1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme.Scheme,
@@ -92,8 +101,22 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 		mgr.GetEventRecorderFor("Limitador"),
 	)
 
+	// The limitador pods are not reachable from the test process. The fake limitador
+	// serves the limits of the limits ConfigMap of the Limitador object owning the pod
+	fakeLimitador = fake.NewServer(fakeLimitadorLimits(mgr.GetAPIReader()))
+
 	err = (&LimitadorReconciler{
 		BaseReconciler: limitadorBaseReconciler,
+		LimitsClient:   fakeLimitador.Client(),
+	}).SetupWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&RateLimitDefinitionReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("ratelimitdefinition"),
+			mgr.GetEventRecorderFor("RateLimitDefinition"),
+		),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -102,6 +125,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 		err = mgr.Start(ctrl.SetupSignalHandler())
 		Expect(err).ToNot(HaveOccurred(), "failed to run manager")
 		gexec.KillAndWait(4 * time.Second)
+		fakeLimitador.Close()
 
 		// Teardown the test environment once controller is finished.
 		// Otherwise from Kubernetes 1.21+, teardown timeouts waiting on
@@ -147,6 +171,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	Expect(scheme.AddToScheme(s)).To(Succeed())
 	err := limitadorv1alpha1.AddToScheme(s)
 	Expect(err).NotTo(HaveOccurred())
+	Expect(monitoringv1.AddToScheme(s)).To(Succeed())
 
 	// Set the shared configuration
 	k8sClient, err = client.New(cfg, client.Options{Scheme: s})
@@ -154,6 +179,52 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	Expect(k8sClient).NotTo(BeNil())
 })
 
+// fakeLimitadorStaleLimitsAnnotation makes the fake limitador serve the limits of the limits ConfigMap
+// of the annotated Limitador object without their last limit, as a limitador instance failing to reload
+const fakeLimitadorStaleLimitsAnnotation = "test.limitador.kuadrant.io/stale-limits"
+
+// fakeLimitadorLimits returns the limits of the limits ConfigMap of the Limitador object
+// owning the pod with the IP of the host
+func fakeLimitadorLimits(reader client.Reader) fake.LimitsFunc {
+	return func(ctx context.Context, host, namespace string) ([]limitadorv1alpha1.RateLimit, error) {
+		podList := &corev1.PodList{}
+		if err := reader.List(ctx, podList, client.MatchingLabels{helpers.LabelKeyApp: helpers.LimitadorAppName}); err != nil {
+			return nil, err
+		}
+
+		for _, pod := range podList.Items {
+			if pod.Status.PodIP != fake.HostIP(host) {
+				continue
+			}
+
+			limitadorObj := &limitadorv1alpha1.Limitador{}
+			limitadorKey := client.ObjectKey{Name: pod.Labels[helpers.LabelKeyLimitadorResource], Namespace: pod.Namespace}
+			if err := reader.Get(ctx, limitadorKey, limitadorObj); err != nil {
+				return nil, err
+			}
+
+			cm := &corev1.ConfigMap{}
+			if err := reader.Get(ctx, client.ObjectKey{Name: limitador.LimitsConfigMapName(limitadorObj), Namespace: pod.Namespace}, cm); err != nil {
+				return nil, err
+			}
+
+			limits, err := limitador.ConfigMapLimits(cm)
+			if err != nil {
+				return nil, err
+			}
+
+			namespaceLimits := limitador.LimitsByNamespace(limits)[namespace]
+			if _, ok := limitadorObj.Annotations[fakeLimitadorStaleLimitsAnnotation]; ok && len(namespaceLimits) > 0 {
+				namespaceLimits = namespaceLimits[:len(namespaceLimits)-1]
+			}
+
+			return namespaceLimits, nil
+		}
+
+		return nil, fmt.Errorf("no limitador pod with IP %s", host)
+	}
+}
+
 var _ = SynchronizedAfterSuite(func() {}, func() {
 	By("tearing down the test environment")
 })
@@ -24,10 +24,14 @@ import (
 	"runtime"
 	"time"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
@@ -43,8 +47,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	limitadorv1beta1 "github.com/kuadrant/limitador-operator/api/v1beta1"
 	"github.com/kuadrant/limitador-operator/controllers"
 	"github.com/kuadrant/limitador-operator/pkg/helpers"
+	"github.com/kuadrant/limitador-operator/pkg/limitador"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient"
 	"github.com/kuadrant/limitador-operator/pkg/log"
 	"github.com/kuadrant/limitador-operator/pkg/observability"
 	"github.com/kuadrant/limitador-operator/pkg/reconcilers"
@@ -64,6 +71,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(limitadorv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(limitadorv1beta1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 
 	logger := log.NewLogger(
@@ -125,16 +134,41 @@ func main() {
 					helpers.LabelKeyApp: helpers.LimitadorAppName,
 				}),
 			},
+			&corev1.Service{}: {
+				Label: labels.SelectorFromSet(labels.Set{
+					helpers.LabelKeyApp: helpers.LimitadorAppName,
+				}),
+			},
 			&appsv1.Deployment{}: {
 				Label: labels.SelectorFromSet(labels.Set{
 					helpers.LabelKeyApp: helpers.LimitadorAppName,
 				}),
 			},
+			&appsv1.StatefulSet{}: {
+				Label: labels.SelectorFromSet(labels.Set{
+					helpers.LabelKeyApp: helpers.LimitadorAppName,
+				}),
+			},
 			&policyv1.PodDisruptionBudget{}: {
 				Label: labels.SelectorFromSet(labels.Set{
 					helpers.LabelKeyApp: helpers.LimitadorAppName,
 				}),
 			},
+			&autoscalingv2.HorizontalPodAutoscaler{}: {
+				Label: labels.SelectorFromSet(labels.Set{
+					helpers.LabelKeyApp: helpers.LimitadorAppName,
+				}),
+			},
+			&networkingv1.NetworkPolicy{}: {
+				Label: labels.SelectorFromSet(labels.Set{
+					helpers.LabelKeyApp: helpers.LimitadorAppName,
+				}),
+			},
+			&discoveryv1.EndpointSlice{}: {
+				Label: labels.SelectorFromSet(labels.Set{
+					helpers.LabelKeyApp: helpers.LimitadorAppName,
+				}),
+			},
 			&corev1.Pod{}: {
 				Label: labels.SelectorFromSet(labels.Set{
 					helpers.LabelKeyApp: helpers.LimitadorAppName,
@@ -179,11 +213,33 @@ func main() {
 
 	if err = (&controllers.LimitadorReconciler{
 		BaseReconciler: limitadorBaseReconciler,
+		LimitsClient:   limitadorclient.New(nil),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create Limitador controller")
 		os.Exit(1)
 	}
 
+	if err = (&controllers.RateLimitDefinitionReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			mgr.GetAPIReader(),
+			log.Log.WithName("ratelimitdefinition"),
+			mgr.GetEventRecorderFor("RateLimitDefinition"),
+		),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create RateLimitDefinition controller")
+		os.Exit(1)
+	}
+
+	// Webhooks require the serving certificates to be mounted, they can be disabled to run the operator locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&limitadorv1alpha1.Limitador{}).SetupWebhookWithManager(mgr, limitador.GetLimitadorImage()); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Limitador")
+			os.Exit(1)
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
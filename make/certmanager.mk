@@ -0,0 +1,10 @@
+##@ Cert-manager
+
+## Targets to install cert-manager, issuing the serving certificates of the operator webhooks https://cert-manager.io
+
+CERT_MANAGER_VERSION ?= v1.16.1
+
+.PHONY: install-cert-manager
+install-cert-manager: ## Install cert-manager in the K8s cluster specified in ~/.kube/config.
+	kubectl apply -f https://github.com/cert-manager/cert-manager/releases/download/$(CERT_MANAGER_VERSION)/cert-manager.yaml
+	kubectl -n cert-manager wait --timeout=300s --for=condition=Available deployments --all
@@ -7,7 +7,13 @@ import (
 )
 
 const (
-	DeleteTagAnnotation       = "limitador.kuadrant.io/delete"
+	DeleteTagAnnotation = "limitador.kuadrant.io/delete"
+	// ReleasedByAnnotation is set on the objects released by their owner on deletion, with the name of the owner.
+	// Only a new owner of the same name adopts them.
+	ReleasedByAnnotation = "limitador.kuadrant.io/released-by"
+	// RetainPolicyAnnotation records on the persistent volume claims the retain policy of the storage using them,
+	// to release instead of delete the retained ones once the storage is no longer in use.
+	RetainPolicyAnnotation    = "limitador.kuadrant.io/retain-policy"
 	LabelKeyApp               = "app"
 	LabelKeyLimitadorResource = "limitador-resource"
 	LimitadorAppName          = "limitador"
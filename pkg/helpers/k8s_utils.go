@@ -2,6 +2,7 @@ package helpers
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func FindDeploymentStatusCondition(conditions []appsv1.DeploymentCondition, conditionType string) *appsv1.DeploymentCondition {
@@ -13,3 +14,18 @@ func FindDeploymentStatusCondition(conditions []appsv1.DeploymentCondition, cond
 
 	return nil
 }
+
+// IsPodReady returns true when the pod is running and its Ready condition is true
+func IsPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodReady {
+			return pod.Status.Conditions[i].Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,235 @@
+// Package conditions parses the conditions of the limitador limits.
+//
+// Limitador accepts two forms of conditions:
+//
+//   - the legacy form, comparing a selector with a quoted string literal,
+//     e.g. `req.method == "GET"` or `descriptors[0]['app'] != 'toystore'`
+//   - CEL predicates, e.g. `req.method == "GET" && size(req.path) > 1`
+//
+// Conditions matching the legacy form are reported as such. Any other condition is parsed as CEL.
+package conditions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+)
+
+type Kind string
+
+const (
+	KindLegacy Kind = "legacy"
+	KindCEL    Kind = "cel"
+)
+
+type Operator string
+
+const (
+	OperatorEqual    Operator = "=="
+	OperatorNotEqual Operator = "!="
+)
+
+// Condition is a parsed condition
+type Condition struct {
+	Kind       Kind
+	Expression string
+
+	// Selector, Operator and Value are only set for legacy conditions
+	Selector string
+	Operator Operator
+	Value    string
+
+	// References lists the selectors the condition refers to, i.e. the selector of a legacy condition,
+	// or the identifiers and the field selections chained to them of a CEL predicate,
+	// e.g. `req` and `req.method` for `req.method == "GET"`
+	References []string
+}
+
+// Issue locates a parse error in the condition.
+// Line and Column are 1-based.
+type Issue struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+}
+
+// ParseError is returned when the condition is neither a legacy condition nor a valid CEL predicate
+type ParseError struct {
+	Condition string
+	Issues    []Issue
+}
+
+func (e *ParseError) Error() string {
+	issues := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	return strings.Join(issues, "; ")
+}
+
+// celEnv only parses, so it does not need any variable declaration
+var celEnv = mustNewCELEnv()
+
+func mustNewCELEnv() *cel.Env {
+	env, err := cel.NewEnv()
+	if err != nil {
+		panic(err)
+	}
+	return env
+}
+
+// Parse parses the condition. A non nil error is always a *ParseError.
+func Parse(condition string) (*Condition, error) {
+	if strings.TrimSpace(condition) == "" {
+		return nil, &ParseError{
+			Condition: condition,
+			Issues:    []Issue{{Line: 1, Column: 1, Message: "empty condition"}},
+		}
+	}
+
+	if parsed, ok := parseLegacy(condition); ok {
+		return parsed, nil
+	}
+
+	parsed, iss := celEnv.Parse(condition)
+	if iss != nil && iss.Err() != nil {
+		parseErr := &ParseError{Condition: condition}
+		for _, celErr := range iss.Errors() {
+			parseErr.Issues = append(parseErr.Issues, Issue{
+				Line:    celErr.Location.Line(),
+				Column:  celErr.Location.Column() + 1,
+				Message: celErr.Message,
+			})
+		}
+		return nil, parseErr
+	}
+
+	return &Condition{Kind: KindCEL, Expression: condition, References: celReferences(parsed.NativeRep().Expr())}, nil
+}
+
+// celReferences returns the identifiers and the field selections chained to them, in order of appearance
+func celReferences(expr ast.Expr) []string {
+	references := []string{}
+	ast.PreOrderVisit(expr, ast.NewExprVisitor(func(e ast.Expr) {
+		if name, ok := celSelectorName(e); ok && !slices.Contains(references, name) {
+			references = append(references, name)
+		}
+	}))
+
+	return references
+}
+
+// celSelectorName returns the dotted name of an identifier, or of a field selection chained to an identifier
+func celSelectorName(expr ast.Expr) (string, bool) {
+	switch expr.Kind() {
+	case ast.IdentKind:
+		return expr.AsIdent(), true
+	case ast.SelectKind:
+		sel := expr.AsSelect()
+		operand, ok := celSelectorName(sel.Operand())
+		if !ok {
+			return "", false
+		}
+		return operand + "." + sel.FieldName(), true
+	default:
+		return "", false
+	}
+}
+
+// parseLegacy parses conditions of the form `<selector> <operator> <quoted string>`.
+// The selector must not contain whitespaces nor quotes outside of brackets.
+func parseLegacy(condition string) (*Condition, bool) {
+	expression := strings.TrimSpace(condition)
+
+	selectorEnd := legacySelectorEnd(expression)
+	if selectorEnd <= 0 {
+		return nil, false
+	}
+	selector := expression[:selectorEnd]
+
+	rest := strings.TrimLeftFunc(expression[selectorEnd:], unicode.IsSpace)
+	var operator Operator
+	switch {
+	case strings.HasPrefix(rest, string(OperatorEqual)):
+		operator = OperatorEqual
+	case strings.HasPrefix(rest, string(OperatorNotEqual)):
+		operator = OperatorNotEqual
+	default:
+		return nil, false
+	}
+
+	literal := strings.TrimSpace(rest[len(operator):])
+	if len(literal) < 2 {
+		return nil, false
+	}
+	quote := literal[0]
+	if (quote != '"' && quote != '\'') || literal[len(literal)-1] != quote {
+		return nil, false
+	}
+	value := literal[1 : len(literal)-1]
+	if strings.ContainsRune(value, rune(quote)) {
+		return nil, false
+	}
+
+	return &Condition{
+		Kind:       KindLegacy,
+		Expression: expression,
+		Selector:   selector,
+		Operator:   operator,
+		Value:      value,
+		References: []string{selector},
+	}, true
+}
+
+// legacySelectorEnd returns the offset right after the selector at the beginning of the expression,
+// or -1 when the expression does not start with a legacy selector
+func legacySelectorEnd(expression string) int {
+	depth := 0
+	for pos, char := range expression {
+		switch {
+		case char == '[':
+			depth++
+		case char == ']':
+			depth--
+			if depth < 0 {
+				return -1
+			}
+		case depth > 0:
+			// anything goes within brackets, e.g. descriptors[0]['app']
+		case unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_' || char == '.' || char == '-':
+		default:
+			if depth != 0 {
+				return -1
+			}
+			return pos
+		}
+	}
+
+	return -1
+}
+
+// Normalize returns the canonical form of the condition, e.g. `req.method == "GET"` for `req.method=='GET'`,
+// so the conditions differing only by their formatting, like the ones limitador reports, compare equal.
+// The conditions that cannot be parsed as CEL are only trimmed.
+func Normalize(condition string) string {
+	parsed, iss := celEnv.Parse(condition)
+	if iss != nil && iss.Err() != nil {
+		return strings.TrimSpace(condition)
+	}
+
+	normalized, err := cel.AstToString(parsed)
+	if err != nil {
+		return strings.TrimSpace(condition)
+	}
+
+	return normalized
+}
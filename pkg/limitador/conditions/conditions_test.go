@@ -0,0 +1,107 @@
+package conditions
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("legacy conditions", func(subT *testing.T) {
+		for _, tc := range []struct {
+			condition string
+			expected  Condition
+		}{
+			{
+				condition: `req.method == "GET"`,
+				expected:  Condition{Kind: KindLegacy, Expression: `req.method == "GET"`, Selector: "req.method", Operator: OperatorEqual, Value: "GET", References: []string{"req.method"}},
+			},
+			{
+				condition: ` get_toy != 'yes' `,
+				expected:  Condition{Kind: KindLegacy, Expression: `get_toy != 'yes'`, Selector: "get_toy", Operator: OperatorNotEqual, Value: "yes", References: []string{"get_toy"}},
+			},
+			{
+				condition: `descriptors[0]['app']=="toy store"`,
+				expected:  Condition{Kind: KindLegacy, Expression: `descriptors[0]['app']=="toy store"`, Selector: "descriptors[0]['app']", Operator: OperatorEqual, Value: "toy store", References: []string{"descriptors[0]['app']"}},
+			},
+		} {
+			parsed, err := Parse(tc.condition)
+			assert.NilError(subT, err, tc.condition)
+			assert.DeepEqual(subT, *parsed, tc.expected)
+		}
+	})
+
+	t.Run("cel predicates", func(subT *testing.T) {
+		for _, condition := range []string{
+			`req.method == "GET" && req.path != "/"`,
+			`size(descriptors[0].user) > 3`,
+			`"admin" in auth.identity.groups`,
+		} {
+			parsed, err := Parse(condition)
+			assert.NilError(subT, err, condition)
+			assert.Equal(subT, parsed.Kind, KindCEL)
+			assert.Equal(subT, parsed.Expression, condition)
+		}
+	})
+
+	t.Run("cel predicate references", func(subT *testing.T) {
+		for _, tc := range []struct {
+			condition  string
+			references []string
+		}{
+			{
+				condition:  `req.method == "GET" && req.path != "/"`,
+				references: []string{"req.method", "req", "req.path"},
+			},
+			{
+				condition:  `size(descriptors[0].user) > 3 && has(auth.identity)`,
+				references: []string{"descriptors", "auth.identity", "auth"},
+			},
+			{
+				condition:  `"admin" in auth.identity.groups || user_id == org_id`,
+				references: []string{"auth.identity.groups", "auth.identity", "auth", "user_id", "org_id"},
+			},
+		} {
+			parsed, err := Parse(tc.condition)
+			assert.NilError(subT, err, tc.condition)
+			assert.DeepEqual(subT, parsed.References, tc.references)
+		}
+	})
+
+	t.Run("empty condition", func(subT *testing.T) {
+		_, err := Parse("  ")
+		var parseErr *ParseError
+		assert.Assert(subT, errors.As(err, &parseErr))
+		assert.DeepEqual(subT, parseErr.Issues, []Issue{{Line: 1, Column: 1, Message: "empty condition"}})
+	})
+
+	t.Run("errors are located", func(subT *testing.T) {
+		_, err := Parse(`req.method == "GET`)
+		var parseErr *ParseError
+		assert.Assert(subT, errors.As(err, &parseErr))
+		assert.Assert(subT, len(parseErr.Issues) > 0)
+		assert.Equal(subT, parseErr.Issues[0].Line, 1)
+		assert.Equal(subT, parseErr.Issues[0].Column, 15)
+
+		_, err = Parse(`a == 'b' &&`)
+		assert.Assert(subT, errors.As(err, &parseErr))
+		assert.Equal(subT, parseErr.Condition, `a == 'b' &&`)
+		assert.Assert(subT, len(parseErr.Issues) > 0)
+		assert.ErrorContains(subT, err, "1:")
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	for _, tc := range []struct {
+		condition  string
+		normalized string
+	}{
+		{condition: `req.method=='GET'`, normalized: `req.method == "GET"`},
+		{condition: ` descriptors[0]['app'] != "toystore" `, normalized: `descriptors[0]["app"] != "toystore"`},
+		{condition: `(req.method == 'GET') && size(req.path)>1`, normalized: `req.method == "GET" && size(req.path) > 1`},
+		{condition: ` a == 'b' && `, normalized: `a == 'b' &&`},
+	} {
+		assert.Equal(t, Normalize(tc.condition), tc.normalized, tc.condition)
+	}
+}
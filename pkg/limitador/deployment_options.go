@@ -19,6 +19,7 @@ type DeploymentOptions struct {
 	DeploymentStrategy appsv1.DeploymentStrategy
 	EnvVar             []corev1.EnvVar
 	ImagePullSecrets   []corev1.LocalObjectReference
+	PodAnnotations     map[string]string
 }
 
 type DeploymentStorageOptions struct {
@@ -26,6 +27,8 @@ type DeploymentStorageOptions struct {
 	VolumeMounts       []corev1.VolumeMount
 	Volumes            []corev1.Volume
 	DeploymentStrategy appsv1.DeploymentStrategy
+	EnvVar             []corev1.EnvVar
+	PodAnnotations     map[string]string
 }
 
 const (
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"k8s.io/utils/env"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
 )
 
 const (
@@ -17,3 +19,18 @@ var (
 func GetLimitadorImage() string {
 	return env.GetString("RELATED_IMAGE_LIMITADOR", defaultImage)
 }
+
+// Image returns the limitador image of the Limitador object. The image written by the defaulting webhook
+// is resolved again, like an unset one, so it follows the version and the limitador image of the operator.
+func Image(limitadorObj *limitadorv1alpha1.Limitador) string {
+	if limitadorObj.Spec.Image != nil && !limitadorObj.IsImageDefaulted() {
+		return *limitadorObj.Spec.Image
+	}
+
+	// deprecated
+	if limitadorObj.Spec.Version != nil {
+		return fmt.Sprintf("%s:%s", LimitadorRepository, *limitadorObj.Spec.Version)
+	}
+
+	return GetLimitadorImage()
+}
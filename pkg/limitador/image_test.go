@@ -4,8 +4,39 @@ import (
 	"testing"
 
 	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
 )
 
 func TestLimitadorDefaultImage(t *testing.T) {
 	assert.Equal(t, GetLimitadorImage(), "quay.io/kuadrant/limitador:latest")
 }
+
+func TestImage(t *testing.T) {
+	t.Run("default image", func(subT *testing.T) {
+		limObj := &limitadorv1alpha1.Limitador{}
+		assert.Equal(subT, Image(limObj), "quay.io/kuadrant/limitador:latest")
+	})
+
+	t.Run("version image", func(subT *testing.T) {
+		limObj := &limitadorv1alpha1.Limitador{Spec: limitadorv1alpha1.LimitadorSpec{Version: ptr.To("v1.6.0")}}
+		assert.Equal(subT, Image(limObj), "quay.io/kuadrant/limitador:v1.6.0")
+	})
+
+	t.Run("image set by the user", func(subT *testing.T) {
+		limObj := &limitadorv1alpha1.Limitador{Spec: limitadorv1alpha1.LimitadorSpec{Image: ptr.To("example.com/limitador:custom")}}
+		assert.Equal(subT, Image(limObj), "example.com/limitador:custom")
+	})
+
+	t.Run("defaulted image resolved again", func(subT *testing.T) {
+		limObj := &limitadorv1alpha1.Limitador{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{limitadorv1alpha1.DefaultedImageAnnotation: "quay.io/kuadrant/limitador:v1.0.0"},
+			},
+			Spec: limitadorv1alpha1.LimitadorSpec{Image: ptr.To("quay.io/kuadrant/limitador:v1.0.0")},
+		}
+		assert.Equal(subT, Image(limObj), "quay.io/kuadrant/limitador:latest")
+	})
+}
@@ -2,13 +2,17 @@ package limitador
 
 import (
 	"fmt"
+	"maps"
+	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
 	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
@@ -17,10 +21,18 @@ import (
 
 const (
 	StatusEndpoint = "/status"
+
+	// ShadowMaxValue is the maximum value of the shadow limits, as rendered for limitador.
+	// It is the largest integer the Lua scripts of the Redis storage represent exactly.
+	ShadowMaxValue = 1<<53 - 1
+
+	// LimitsConfigMapShadowAnnotation holds the number of limits in shadow mode of the limits ConfigMap,
+	// as the rendered limits have no mode
+	LimitsConfigMapShadowAnnotation = "limitador.kuadrant.io/shadow-limits"
 )
 
 func Service(limitador *limitadorv1alpha1.Limitador) *v1.Service {
-	return &v1.Service{
+	service := &v1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Service",
 			APIVersion: "v1",
@@ -45,27 +57,39 @@ func Service(limitador *limitadorv1alpha1.Limitador) *v1.Service {
 					TargetPort: intstr.FromString("grpc"),
 				},
 			},
-			Selector:  SelectorLabels(limitador),
-			ClusterIP: v1.ClusterIPNone,
-			Type:      v1.ServiceTypeClusterIP,
+			Selector: SelectorLabels(limitador),
 		},
 	}
-}
-
-func Deployment(limitador *limitadorv1alpha1.Limitador, deploymentOptions DeploymentOptions) *appsv1.Deployment {
-	replicas := limitador.GetReplicas()
 
-	image := GetLimitadorImage()
+	serviceType, headless := limitador.ServiceType()
+	service.Spec.Type = serviceType
+	if headless {
+		service.Spec.ClusterIP = v1.ClusterIPNone
+	}
 
-	// deprecated
-	if limitador.Spec.Version != nil {
-		image = fmt.Sprintf("%s:%s", LimitadorRepository, *limitador.Spec.Version)
+	if serviceSpec := limitador.Spec.Service; serviceSpec != nil {
+		if len(serviceSpec.Annotations) > 0 {
+			service.Annotations = maps.Clone(serviceSpec.Annotations)
+		}
+		service.Spec.IPFamilyPolicy = serviceSpec.IPFamilyPolicy
+		service.Spec.TrafficDistribution = serviceSpec.TrafficDistribution
+		if serviceSpec.ExternalTrafficPolicy != nil {
+			service.Spec.ExternalTrafficPolicy = *serviceSpec.ExternalTrafficPolicy
+		}
 	}
 
-	if limitador.Spec.Image != nil {
-		image = *limitador.Spec.Image
+	return service
+}
+
+func Deployment(limitador *limitadorv1alpha1.Limitador, deploymentOptions DeploymentOptions) *appsv1.Deployment {
+	// The HorizontalPodAutoscaler owns the replicas when autoscaling
+	var replicas *int32
+	if limitador.Spec.Autoscaling == nil {
+		replicas = ptr.To(limitador.GetReplicas())
 	}
 
+	image := Image(limitador)
+
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Deployment",
@@ -77,18 +101,25 @@ func Deployment(limitador *limitadorv1alpha1.Limitador, deploymentOptions Deploy
 			Labels:    Labels(limitador),
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			Replicas: replicas,
 			Strategy: deploymentOptions.DeploymentStrategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabels(limitador),
 			},
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: Labels(limitador),
+					Labels:      PodLabels(limitador),
+					Annotations: PodAnnotations(limitador, deploymentOptions.PodAnnotations),
 				},
 				Spec: v1.PodSpec{
-					Affinity:         limitador.Spec.Affinity,
-					ImagePullSecrets: deploymentOptions.ImagePullSecrets,
+					Affinity:                  limitador.Spec.Affinity,
+					Tolerations:               limitador.Spec.Tolerations,
+					NodeSelector:              limitador.Spec.NodeSelector,
+					TopologySpreadConstraints: topologySpreadConstraints(limitador),
+					PriorityClassName:         ptr.Deref(limitador.Spec.PriorityClassName, ""),
+					ServiceAccountName:        ptr.Deref(limitador.Spec.ServiceAccountName, ""),
+					SecurityContext:           limitador.Spec.PodSecurityContext,
+					ImagePullSecrets:          deploymentOptions.ImagePullSecrets,
 					Containers: []v1.Container{
 						{
 							Name:    "limitador",
@@ -108,34 +139,10 @@ func Deployment(limitador *limitadorv1alpha1.Limitador, deploymentOptions Deploy
 									Protocol:      v1.ProtocolTCP,
 								},
 							},
-							LivenessProbe: &v1.Probe{
-								ProbeHandler: v1.ProbeHandler{
-									HTTPGet: &v1.HTTPGetAction{
-										Path:   StatusEndpoint,
-										Port:   intstr.FromInt(int(limitador.HTTPPort())),
-										Scheme: v1.URISchemeHTTP,
-									},
-								},
-								InitialDelaySeconds: 5,
-								TimeoutSeconds:      2,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								FailureThreshold:    3,
-							},
-							ReadinessProbe: &v1.Probe{
-								ProbeHandler: v1.ProbeHandler{
-									HTTPGet: &v1.HTTPGetAction{
-										Path:   StatusEndpoint,
-										Port:   intstr.FromInt(int(limitador.HTTPPort())),
-										Scheme: v1.URISchemeHTTP,
-									},
-								},
-								InitialDelaySeconds: 5,
-								TimeoutSeconds:      5,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								FailureThreshold:    3,
-							},
+							LivenessProbe:   LivenessProbe(limitador),
+							ReadinessProbe:  ReadinessProbe(limitador),
+							StartupProbe:    StartupProbe(limitador),
+							SecurityContext: limitador.Spec.SecurityContext,
 							Resources:       *limitador.GetResourceRequirements(),
 							VolumeMounts:    deploymentOptions.VolumeMounts,
 							ImagePullPolicy: v1.PullIfNotPresent,
@@ -148,12 +155,45 @@ func Deployment(limitador *limitadorv1alpha1.Limitador, deploymentOptions Deploy
 	}
 }
 
-func LimitsConfigMap(limitadorObj *limitadorv1alpha1.Limitador) (*v1.ConfigMap, error) {
-	limitsMarshalled, marshallErr := yaml.Marshal(limitadorObj.Limits())
+// topologySpreadConstraints defaults the label selector of the constraints to the limitador pods
+func topologySpreadConstraints(limitador *limitadorv1alpha1.Limitador) []v1.TopologySpreadConstraint {
+	if limitador.Spec.TopologySpreadConstraints == nil {
+		return nil
+	}
+
+	constraints := make([]v1.TopologySpreadConstraint, 0, len(limitador.Spec.TopologySpreadConstraints))
+	for _, constraint := range limitador.Spec.TopologySpreadConstraints {
+		constraint := *constraint.DeepCopy()
+		if constraint.LabelSelector == nil {
+			constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: SelectorLabels(limitador)}
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints
+}
+
+// LimitsConfigMap renders the inline limits of the Limitador object, followed by the limits
+// of its spec.limitsFrom sources and the ones accepted from the rate limit definitions selecting it
+func LimitsConfigMap(limitadorObj *limitadorv1alpha1.Limitador, externalLimits []limitadorv1alpha1.RateLimit) (*v1.ConfigMap, error) {
+	limits := []limitadorv1alpha1.RateLimit{}
+	shadow := 0
+	for _, limit := range append(append([]limitadorv1alpha1.RateLimit{}, limitadorObj.Limits()...), externalLimits...) {
+		if limit.IsShadow() {
+			shadow++
+		}
+		limits = append(limits, renderLimit(limit))
+	}
+	limitsMarshalled, marshallErr := yaml.Marshal(limits)
 	if marshallErr != nil {
 		return nil, marshallErr
 	}
 
+	var annotations map[string]string
+	if shadow > 0 {
+		annotations = map[string]string{LimitsConfigMapShadowAnnotation: strconv.Itoa(shadow)}
+	}
+
 	return &v1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ConfigMap",
@@ -163,13 +203,25 @@ func LimitsConfigMap(limitadorObj *limitadorv1alpha1.Limitador) (*v1.ConfigMap,
 			LimitadorConfigFileName: string(limitsMarshalled),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      LimitsConfigMapName(limitadorObj),
-			Namespace: limitadorObj.Namespace,
-			Labels:    Labels(limitadorObj),
+			Name:        LimitsConfigMapName(limitadorObj),
+			Namespace:   limitadorObj.Namespace,
+			Labels:      Labels(limitadorObj),
+			Annotations: annotations,
 		},
 	}, nil
 }
 
+// renderLimit translates the limit to the limitador format. Limitador has no shadow mode,
+// shadow limits are rendered with a maximum value never reached.
+func renderLimit(limit limitadorv1alpha1.RateLimit) limitadorv1alpha1.RateLimit {
+	if limit.IsShadow() {
+		limit.MaxValue = ShadowMaxValue
+	}
+	limit.Mode = ""
+
+	return limit
+}
+
 func LimitsConfigMapName(limitadorObj *limitadorv1alpha1.Limitador) string {
 	return fmt.Sprintf("limitador-limits-config-%s", limitadorObj.Name)
 }
@@ -182,6 +234,23 @@ func PVCName(limitadorObj *limitadorv1alpha1.Limitador) string {
 	return fmt.Sprintf("limitador-%s", limitadorObj.Name)
 }
 
+// RetainedPVCNames returns the names of the persistent volume claims of the storage
+// to be kept when the Limitador object is deleted
+func RetainedPVCNames(limitadorObj *limitadorv1alpha1.Limitador) []string {
+	var names []string
+	if limitadorObj.Spec.Storage != nil && limitadorObj.Spec.Storage.Disk != nil &&
+		ptr.Deref(limitadorObj.Spec.Storage.Disk.RetainPolicy, limitadorv1alpha1.RetainPolicyDelete) == limitadorv1alpha1.RetainPolicyRetain {
+		names = append(names, PVCName(limitadorObj))
+	}
+
+	if managed := limitadorObj.ManagedRedis(); managed != nil &&
+		ptr.Deref(managed.RetainPolicy, limitadorv1alpha1.RetainPolicyDelete) == limitadorv1alpha1.RetainPolicyRetain {
+		names = append(names, ManagedRedisName(limitadorObj))
+	}
+
+	return names
+}
+
 func DeploymentName(limitadorObj *limitadorv1alpha1.Limitador) string {
 	return fmt.Sprintf("limitador-%s", limitadorObj.Name)
 }
@@ -215,6 +284,66 @@ func PodDisruptionBudget(limitadorObj *limitadorv1alpha1.Limitador) *policyv1.Po
 	return pdb
 }
 
+func HorizontalPodAutoscaler(limitadorObj *limitadorv1alpha1.Limitador) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      HorizontalPodAutoscalerName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    Labels(limitadorObj),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       DeploymentName(limitadorObj),
+			},
+		},
+	}
+
+	autoscaling := limitadorObj.Spec.Autoscaling
+	if autoscaling == nil {
+		helpers.TagObjectToDelete(hpa)
+		return hpa
+	}
+
+	hpa.Spec.MinReplicas = autoscaling.MinReplicas
+	hpa.Spec.MaxReplicas = autoscaling.MaxReplicas
+
+	resourceTargets := []struct {
+		name       v1.ResourceName
+		percentage *int32
+	}{
+		{v1.ResourceCPU, autoscaling.TargetCPUUtilizationPercentage},
+		{v1.ResourceMemory, autoscaling.TargetMemoryUtilizationPercentage},
+	}
+	for _, target := range resourceTargets {
+		if target.percentage == nil {
+			continue
+		}
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: target.name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: target.percentage,
+				},
+			},
+		})
+	}
+	hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscaling.Metrics...)
+
+	return hpa
+}
+
+func HorizontalPodAutoscalerName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-%s", limitadorObj.Name)
+}
+
 func PodDisruptionBudgetName(limitadorObj *limitadorv1alpha1.Limitador) string {
 	return fmt.Sprintf("limitador-%s", limitadorObj.Name)
 }
@@ -226,7 +355,39 @@ func SelectorLabels(limitador *limitadorv1alpha1.Limitador) map[string]string {
 	}
 }
 
+// Labels are the labels of the objects owned by the Limitador object.
+// The common labels of the spec do not override the labels set by the operator.
 func Labels(limitador *limitadorv1alpha1.Limitador) map[string]string {
+	labels := map[string]string{}
+	helpers.MergeMapStringString(&labels, limitador.Spec.CommonLabels)
+	helpers.MergeMapStringString(&labels, operatorLabels(limitador))
+	return labels
+}
+
+// PodLabels are the labels of the limitador pods: the common labels, the pod template labels
+// and the labels set by the operator, from lowest to highest precedence
+func PodLabels(limitador *limitadorv1alpha1.Limitador) map[string]string {
+	labels := map[string]string{}
+	helpers.MergeMapStringString(&labels, limitador.Spec.CommonLabels)
+	helpers.MergeMapStringString(&labels, limitador.PodTemplateLabels())
+	helpers.MergeMapStringString(&labels, operatorLabels(limitador))
+	return labels
+}
+
+// PodAnnotations merges the pod template annotations with the annotations set by the operator.
+// The limits ConfigMap resource version annotation is set on the pods by the operator, not in the template.
+func PodAnnotations(limitador *limitadorv1alpha1.Limitador, operatorAnnotations map[string]string) map[string]string {
+	annotations := map[string]string{}
+	helpers.MergeMapStringString(&annotations, limitador.PodTemplateAnnotations())
+	delete(annotations, limitadorv1alpha1.PodAnnotationConfigMapResourceVersion)
+	helpers.MergeMapStringString(&annotations, operatorAnnotations)
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+func operatorLabels(limitador *limitadorv1alpha1.Limitador) map[string]string {
 	return map[string]string{
 		helpers.LabelKeyApp:               helpers.LimitadorAppName,
 		helpers.LabelKeyLimitadorResource: limitador.Name,
@@ -265,6 +426,10 @@ func PVC(limitador *limitadorv1alpha1.Limitador) *v1.PersistentVolumeClaim {
 		return pvc
 	}
 
+	pvc.Annotations = map[string]string{
+		helpers.RetainPolicyAnnotation: string(ptr.Deref(limitador.Spec.Storage.Disk.RetainPolicy, limitadorv1alpha1.RetainPolicyDelete)),
+	}
+
 	if limitador.Spec.Storage.Disk.PVC != nil {
 		pvc.Spec.StorageClassName = limitador.Spec.Storage.Disk.PVC.StorageClassName
 		if limitador.Spec.Storage.Disk.PVC.VolumeName != nil {
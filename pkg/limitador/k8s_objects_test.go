@@ -1,9 +1,11 @@
 package limitador
 
 import (
+	"strings"
 	"testing"
 
 	"gotest.tools/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,6 +66,48 @@ func TestServiceName(t *testing.T) {
 	assert.Equal(t, name, "limitador-my-limitador-instance")
 }
 
+func TestService(t *testing.T) {
+	t.Run("headless service by default", func(subT *testing.T) {
+		limitadorObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		service := Service(limitadorObj)
+		assert.Equal(subT, service.Spec.Type, corev1.ServiceTypeClusterIP)
+		assert.Equal(subT, service.Spec.ClusterIP, corev1.ClusterIPNone)
+		assert.Assert(subT, service.Annotations == nil)
+		assert.Assert(subT, service.Spec.IPFamilyPolicy == nil)
+		assert.Equal(subT, service.Spec.ExternalTrafficPolicy, corev1.ServiceExternalTrafficPolicy(""))
+		assert.DeepEqual(subT, service.Spec.Selector, SelectorLabels(limitadorObj))
+	})
+
+	t.Run("regular cluster IP service", func(subT *testing.T) {
+		limitadorObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limitadorObj.Spec.Service = &limitadorv1alpha1.ServiceSpec{
+			Type:                ptr.To(corev1.ServiceTypeClusterIP),
+			IPFamilyPolicy:      ptr.To(corev1.IPFamilyPolicyPreferDualStack),
+			TrafficDistribution: ptr.To(corev1.ServiceTrafficDistributionPreferClose),
+		}
+		service := Service(limitadorObj)
+		assert.Equal(subT, service.Spec.Type, corev1.ServiceTypeClusterIP)
+		assert.Equal(subT, service.Spec.ClusterIP, "")
+		assert.DeepEqual(subT, service.Spec.IPFamilyPolicy, ptr.To(corev1.IPFamilyPolicyPreferDualStack))
+		assert.DeepEqual(subT, service.Spec.TrafficDistribution, ptr.To(corev1.ServiceTrafficDistributionPreferClose))
+	})
+
+	t.Run("load balancer service", func(subT *testing.T) {
+		limitadorObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limitadorObj.Spec.Service = &limitadorv1alpha1.ServiceSpec{
+			Type:                  ptr.To(corev1.ServiceTypeLoadBalancer),
+			Annotations:           map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+			ExternalTrafficPolicy: ptr.To(corev1.ServiceExternalTrafficPolicyLocal),
+		}
+		service := Service(limitadorObj)
+		assert.Equal(subT, service.Spec.Type, corev1.ServiceTypeLoadBalancer)
+		assert.Equal(subT, service.Spec.ClusterIP, "")
+		assert.DeepEqual(subT, service.Annotations, map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"})
+		assert.Equal(subT, service.Spec.ExternalTrafficPolicy, corev1.ServiceExternalTrafficPolicyLocal)
+		assert.Equal(subT, len(service.Spec.Ports), 2)
+	})
+}
+
 func TestDeployment(t *testing.T) {
 	t.Run("default replicas", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
@@ -81,6 +125,14 @@ func TestDeployment(t *testing.T) {
 		assert.Assert(subT, *deployment.Spec.Replicas == 2)
 	})
 
+	t.Run("replicas unset when autoscaling", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Replicas = nil
+		limObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{MaxReplicas: 3}
+		deployment := Deployment(limObj, DeploymentOptions{})
+		assert.Assert(subT, deployment.Spec.Replicas == nil)
+	})
+
 	t.Run("labels", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
 		deployment := Deployment(limObj, DeploymentOptions{})
@@ -206,6 +258,70 @@ func TestDeployment(t *testing.T) {
 		)
 	})
 
+	t.Run("pod annotations", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		deployment := Deployment(limObj, DeploymentOptions{
+			PodAnnotations: map[string]string{limitadorv1alpha1.PodAnnotationStorageConfigHash: "abc"},
+		})
+
+		assert.DeepEqual(subT, deployment.Spec.Template.Annotations,
+			map[string]string{limitadorv1alpha1.PodAnnotationStorageConfigHash: "abc"},
+		)
+	})
+
+	t.Run("custom labels", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.CommonLabels = map[string]string{
+			"cost-center":               "rate-limiting",
+			"tier":                      "common",
+			"app.kubernetes.io/part-of": "other",
+		}
+		limObj.Spec.PodTemplate = &limitadorv1alpha1.PodTemplate{
+			Metadata: limitadorv1alpha1.PodTemplateMetadata{
+				Labels: map[string]string{"sidecar.istio.io/inject": "true", "tier": "pod"},
+			},
+		}
+		deployment := Deployment(limObj, DeploymentOptions{})
+
+		assert.Equal(subT, deployment.Labels["cost-center"], "rate-limiting")
+		assert.Equal(subT, deployment.Labels["tier"], "common")
+		assert.Equal(subT, deployment.Labels["app.kubernetes.io/part-of"], "kuadrant")
+		assert.Equal(subT, deployment.Labels["sidecar.istio.io/inject"], "")
+
+		podLabels := deployment.Spec.Template.Labels
+		assert.Equal(subT, podLabels["cost-center"], "rate-limiting")
+		assert.Equal(subT, podLabels["tier"], "pod")
+		assert.Equal(subT, podLabels["sidecar.istio.io/inject"], "true")
+		assert.Equal(subT, podLabels["app.kubernetes.io/part-of"], "kuadrant")
+		assert.DeepEqual(subT, deployment.Spec.Selector.MatchLabels, SelectorLabels(limObj))
+		for key, value := range SelectorLabels(limObj) {
+			assert.Equal(subT, podLabels[key], value)
+		}
+	})
+
+	t.Run("custom pod annotations", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.PodTemplate = &limitadorv1alpha1.PodTemplate{
+			Metadata: limitadorv1alpha1.PodTemplateMetadata{
+				Annotations: map[string]string{
+					"sidecar.istio.io/inject":                               "true",
+					limitadorv1alpha1.PodAnnotationStorageConfigHash:        "user",
+					limitadorv1alpha1.PodAnnotationConfigMapResourceVersion: "1",
+				},
+			},
+		}
+		deployment := Deployment(limObj, DeploymentOptions{
+			PodAnnotations: map[string]string{limitadorv1alpha1.PodAnnotationStorageConfigHash: "abc"},
+		})
+
+		assert.DeepEqual(subT, deployment.Spec.Template.Annotations,
+			map[string]string{
+				"sidecar.istio.io/inject":                        "true",
+				limitadorv1alpha1.PodAnnotationStorageConfigHash: "abc",
+			},
+		)
+	})
+
 	t.Run("imagePullSecrets", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
 		deployment := Deployment(limObj, DeploymentOptions{
@@ -216,6 +332,52 @@ func TestDeployment(t *testing.T) {
 			[]corev1.LocalObjectReference{{Name: "regcred"}},
 		)
 	})
+
+	t.Run("pod scheduling and security", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Tolerations = []corev1.Toleration{
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "limitador", Effect: corev1.TaintEffectNoSchedule},
+		}
+		limObj.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		limObj.Spec.PriorityClassName = ptr.To("high-priority")
+		limObj.Spec.ServiceAccountName = ptr.To("limitador")
+		limObj.Spec.PodSecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}
+		limObj.Spec.SecurityContext = &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(false)}
+
+		podSpec := Deployment(limObj, DeploymentOptions{}).Spec.Template.Spec
+		assert.DeepEqual(subT, podSpec.Tolerations, limObj.Spec.Tolerations)
+		assert.DeepEqual(subT, podSpec.NodeSelector, limObj.Spec.NodeSelector)
+		assert.Equal(subT, podSpec.PriorityClassName, "high-priority")
+		assert.Equal(subT, podSpec.ServiceAccountName, "limitador")
+		assert.DeepEqual(subT, podSpec.SecurityContext, limObj.Spec.PodSecurityContext)
+		assert.DeepEqual(subT, podSpec.Containers[0].SecurityContext, limObj.Spec.SecurityContext)
+	})
+
+	t.Run("pod scheduling and security not set", func(subT *testing.T) {
+		podSpec := Deployment(newTestLimitadorObj("some-name", "some-ns", nil), DeploymentOptions{}).Spec.Template.Spec
+		assert.Assert(subT, podSpec.Tolerations == nil)
+		assert.Assert(subT, podSpec.NodeSelector == nil)
+		assert.Assert(subT, podSpec.TopologySpreadConstraints == nil)
+		assert.Equal(subT, podSpec.PriorityClassName, "")
+		assert.Equal(subT, podSpec.ServiceAccountName, "")
+		assert.Assert(subT, podSpec.SecurityContext == nil)
+		assert.Assert(subT, podSpec.Containers[0].SecurityContext == nil)
+	})
+
+	t.Run("topology spread constraints select limitador pods by default", func(subT *testing.T) {
+		customSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"custom": "label"}}
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: customSelector},
+		}
+
+		constraints := Deployment(limObj, DeploymentOptions{}).Spec.Template.Spec.TopologySpreadConstraints
+		assert.Equal(subT, len(constraints), 2)
+		assert.DeepEqual(subT, constraints[0].LabelSelector, &metav1.LabelSelector{MatchLabels: SelectorLabels(limObj)})
+		assert.DeepEqual(subT, constraints[1].LabelSelector, customSelector)
+		assert.Assert(subT, limObj.Spec.TopologySpreadConstraints[0].LabelSelector == nil)
+	})
 }
 
 func TestPodDisruptionBudgetName(t *testing.T) {
@@ -230,10 +392,57 @@ func TestPodDisruptionBudget(t *testing.T) {
 	assert.DeepEqual(t, pdb.Spec.Selector.MatchLabels, SelectorLabels(limitadorObj))
 }
 
+func TestHorizontalPodAutoscaler(t *testing.T) {
+	t.Run("tagged to delete without autoscaling", func(subT *testing.T) {
+		hpa := HorizontalPodAutoscaler(newTestLimitadorObj("some-name", "some-ns", nil))
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(hpa))
+	})
+
+	t.Run("targets the deployment", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{MinReplicas: ptr.To(int32(2)), MaxReplicas: 5}
+		hpa := HorizontalPodAutoscaler(limObj)
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(hpa))
+		assert.Equal(subT, hpa.Name, "limitador-some-name")
+		assert.Equal(subT, hpa.Spec.ScaleTargetRef.Kind, "Deployment")
+		assert.Equal(subT, hpa.Spec.ScaleTargetRef.Name, DeploymentName(limObj))
+		assert.DeepEqual(subT, hpa.Spec.MinReplicas, ptr.To(int32(2)))
+		assert.Equal(subT, hpa.Spec.MaxReplicas, int32(5))
+		assert.Assert(subT, hpa.Spec.Metrics == nil)
+	})
+
+	t.Run("metrics", func(subT *testing.T) {
+		customMetric := autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "authorized_calls"},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: ptr.To(resource.MustParse("100")),
+				},
+			},
+		}
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Autoscaling = &limitadorv1alpha1.Autoscaling{
+			MaxReplicas:                       5,
+			TargetCPUUtilizationPercentage:    ptr.To(int32(70)),
+			TargetMemoryUtilizationPercentage: ptr.To(int32(80)),
+			Metrics:                           []autoscalingv2.MetricSpec{customMetric},
+		}
+		hpa := HorizontalPodAutoscaler(limObj)
+		assert.Equal(subT, len(hpa.Spec.Metrics), 3)
+		assert.Equal(subT, hpa.Spec.Metrics[0].Resource.Name, corev1.ResourceCPU)
+		assert.DeepEqual(subT, hpa.Spec.Metrics[0].Resource.Target.AverageUtilization, ptr.To(int32(70)))
+		assert.Equal(subT, hpa.Spec.Metrics[1].Resource.Name, corev1.ResourceMemory)
+		assert.DeepEqual(subT, hpa.Spec.Metrics[1].Resource.Target.AverageUtilization, ptr.To(int32(80)))
+		assert.DeepEqual(subT, hpa.Spec.Metrics[2], customMetric)
+	})
+}
+
 func TestLimitsConfigMap(t *testing.T) {
 	t.Run("config map name", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
-		configMap, err := LimitsConfigMap(limObj)
+		configMap, err := LimitsConfigMap(limObj, nil)
 		assert.NilError(subT, err)
 		assert.Assert(subT, configMap != nil)
 		assert.Assert(subT, configMap.Name == LimitsConfigMapName(limObj))
@@ -241,7 +450,7 @@ func TestLimitsConfigMap(t *testing.T) {
 
 	t.Run("config map namespace", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
-		configMap, err := LimitsConfigMap(limObj)
+		configMap, err := LimitsConfigMap(limObj, nil)
 		assert.NilError(subT, err)
 		assert.Assert(subT, configMap != nil)
 		assert.Assert(subT, configMap.Namespace == "some-ns")
@@ -249,7 +458,7 @@ func TestLimitsConfigMap(t *testing.T) {
 
 	t.Run("config map labels", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
-		configMap, err := LimitsConfigMap(limObj)
+		configMap, err := LimitsConfigMap(limObj, nil)
 		assert.NilError(subT, err)
 		assert.Assert(subT, configMap != nil)
 		assert.DeepEqual(subT, configMap.Labels,
@@ -284,7 +493,7 @@ func TestLimitsConfigMap(t *testing.T) {
 		}
 
 		limObj := newTestLimitadorObj("some-name", "some-ns", limits)
-		configMap, err := LimitsConfigMap(limObj)
+		configMap, err := LimitsConfigMap(limObj, nil)
 		assert.NilError(subT, err)
 		assert.Assert(subT, configMap != nil)
 		serializedLimts, ok := configMap.Data[LimitadorConfigFileName]
@@ -297,9 +506,52 @@ func TestLimitsConfigMap(t *testing.T) {
 		assert.DeepEqual(subT, limits, limitsUnMarshalled)
 	})
 
+	t.Run("config map limits with definitions limits", func(subT *testing.T) {
+		inlineLimits := []limitadorv1alpha1.RateLimit{
+			{Conditions: []string{}, Variables: []string{}, MaxValue: 10, Namespace: "my-ns", Seconds: 60, Name: "inline"},
+		}
+		definitionsLimits := []limitadorv1alpha1.RateLimit{
+			{Conditions: []string{}, Variables: []string{}, MaxValue: 5, Namespace: "my-ns", Seconds: 1, Name: "definition"},
+		}
+
+		limObj := newTestLimitadorObj("some-name", "some-ns", inlineLimits)
+		configMap, err := LimitsConfigMap(limObj, definitionsLimits)
+		assert.NilError(subT, err)
+
+		var limitsUnMarshalled []limitadorv1alpha1.RateLimit
+		unmarshallErr := yaml.Unmarshal([]byte(configMap.Data[LimitadorConfigFileName]), &limitsUnMarshalled)
+		assert.NilError(subT, unmarshallErr)
+		assert.DeepEqual(subT, append(inlineLimits, definitionsLimits...), limitsUnMarshalled)
+		// inline limits of the Limitador object must not be modified
+		assert.Assert(subT, len(limObj.Spec.Limits) == 1)
+	})
+
+	t.Run("config map shadow limits", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", []limitadorv1alpha1.RateLimit{
+			{Conditions: []string{}, Variables: []string{}, MaxValue: 10, Namespace: "my-ns", Seconds: 60, Name: "enforced",
+				Mode: limitadorv1alpha1.LimitModeEnforce},
+			{Conditions: []string{}, Variables: []string{}, MaxValue: 10, Namespace: "my-ns", Seconds: 60, Name: "shadow",
+				Mode: limitadorv1alpha1.LimitModeShadow},
+		})
+		configMap, err := LimitsConfigMap(limObj, nil)
+		assert.NilError(subT, err)
+		assert.Assert(subT, !strings.Contains(configMap.Data[LimitadorConfigFileName], "mode"))
+		assert.Equal(subT, configMap.Annotations[LimitsConfigMapShadowAnnotation], "1")
+
+		var limitsUnMarshalled []limitadorv1alpha1.RateLimit
+		unmarshallErr := yaml.Unmarshal([]byte(configMap.Data[LimitadorConfigFileName]), &limitsUnMarshalled)
+		assert.NilError(subT, unmarshallErr)
+		assert.DeepEqual(subT, limitsUnMarshalled, []limitadorv1alpha1.RateLimit{
+			{Conditions: []string{}, Variables: []string{}, MaxValue: 10, Namespace: "my-ns", Seconds: 60, Name: "enforced"},
+			{Conditions: []string{}, Variables: []string{}, MaxValue: ShadowMaxValue, Namespace: "my-ns", Seconds: 60, Name: "shadow"},
+		})
+		// inline limits of the Limitador object must not be modified
+		assert.Equal(subT, limObj.Spec.Limits[1].MaxValue, 10)
+	})
+
 	t.Run("config map nil limits", func(subT *testing.T) {
 		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
-		configMap, err := LimitsConfigMap(limObj)
+		configMap, err := LimitsConfigMap(limObj, nil)
 		assert.NilError(subT, err)
 		assert.Assert(subT, configMap != nil)
 
@@ -318,7 +570,7 @@ func TestLimitsConfigMap(t *testing.T) {
 	t.Run("config map empty limits", func(subT *testing.T) {
 		limits := make([]limitadorv1alpha1.RateLimit, 0)
 		limObj := newTestLimitadorObj("some-name", "some-ns", limits)
-		configMap, err := LimitsConfigMap(limObj)
+		configMap, err := LimitsConfigMap(limObj, nil)
 		assert.NilError(subT, err)
 		assert.Assert(subT, configMap != nil)
 		serializedLimts, ok := configMap.Data[LimitadorConfigFileName]
@@ -396,6 +648,18 @@ func TestPVC(t *testing.T) {
 		)
 	})
 
+	t.Run("retain policy annotation", func(subT *testing.T) {
+		limObj := newDiskStorageLimitador("some-name")
+		assert.DeepEqual(subT, PVC(limObj).Annotations,
+			map[string]string{helpers.RetainPolicyAnnotation: "Delete"},
+		)
+
+		limObj.Spec.Storage.Disk.RetainPolicy = ptr.To(limitadorv1alpha1.RetainPolicyRetain)
+		assert.DeepEqual(subT, PVC(limObj).Annotations,
+			map[string]string{helpers.RetainPolicyAnnotation: "Retain"},
+		)
+	})
+
 	t.Run("default storage class", func(subT *testing.T) {
 		limObj := newDiskStorageLimitador("some-name")
 		pvc := PVC(limObj)
@@ -411,3 +675,29 @@ func TestPVC(t *testing.T) {
 		assert.DeepEqual(subT, pvc.Spec.StorageClassName, ptr.To("myCustomStorage"))
 	})
 }
+
+func TestRetainedPVCNames(t *testing.T) {
+	t.Run("storage deleted by default", func(subT *testing.T) {
+		assert.Assert(subT, RetainedPVCNames(newTestLimitadorObj("some-name", "some-ns", nil)) == nil)
+		assert.Assert(subT, RetainedPVCNames(newDiskStorageLimitador("some-name")) == nil)
+	})
+
+	t.Run("disk storage retained", func(subT *testing.T) {
+		limObj := newDiskStorageLimitador("some-name")
+		limObj.Spec.Storage.Disk.RetainPolicy = ptr.To(limitadorv1alpha1.RetainPolicyRetain)
+		assert.DeepEqual(subT, RetainedPVCNames(limObj), []string{"limitador-some-name"})
+
+		limObj.Spec.Storage.Disk.RetainPolicy = ptr.To(limitadorv1alpha1.RetainPolicyDelete)
+		assert.Assert(subT, RetainedPVCNames(limObj) == nil)
+	})
+
+	t.Run("managed redis storage retained", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Storage = &limitadorv1alpha1.Storage{
+			Redis: &limitadorv1alpha1.Redis{
+				Managed: &limitadorv1alpha1.ManagedRedis{RetainPolicy: ptr.To(limitadorv1alpha1.RetainPolicyRetain)},
+			},
+		}
+		assert.DeepEqual(subT, RetainedPVCNames(limObj), []string{"limitador-some-name-redis"})
+	})
+}
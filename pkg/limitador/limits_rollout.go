@@ -0,0 +1,326 @@
+package limitador
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+const (
+	// LimitsCanaryLabel is set on the canary pods of a limits rollout
+	LimitsCanaryLabel = "limitador.kuadrant.io/limits-canary"
+	// LimitsRolloutAnnotation holds the state of the limits rollout on the canary limits ConfigMap
+	LimitsRolloutAnnotation = "limitador.kuadrant.io/limits-rollout"
+	// PodAnnotationLimitsConfigHash rolls the canary pods out when the limits config rolled out changes
+	PodAnnotationLimitsConfigHash = "limitador.kuadrant.io/limits-config-hash"
+)
+
+func LimitsCanaryConfigMapName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-limits-config-%s-canary", limitadorObj.Name)
+}
+
+func LimitsCanaryDeploymentName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-%s-limits-canary", limitadorObj.Name)
+}
+
+// LimitsCanaryConfigMap returns the ConfigMap holding the limits config loaded by the canary pods,
+// along with the state of its rollout. A nil rollout status tags the ConfigMap to be deleted.
+func LimitsCanaryConfigMap(limitadorObj *limitadorv1alpha1.Limitador, limitsConfigMap *v1.ConfigMap, rollout *limitadorv1alpha1.LimitsRolloutStatus) (*v1.ConfigMap, error) {
+	cm := &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LimitsCanaryConfigMapName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    Labels(limitadorObj),
+		},
+	}
+
+	if rollout == nil {
+		helpers.TagObjectToDelete(cm)
+		return cm, nil
+	}
+
+	rolloutJSON, err := json.Marshal(rollout)
+	if err != nil {
+		return nil, err
+	}
+	cm.Annotations = map[string]string{LimitsRolloutAnnotation: string(rolloutJSON)}
+	cm.Data = map[string]string{LimitadorConfigFileName: limitsConfigMap.Data[LimitadorConfigFileName]}
+
+	return cm, nil
+}
+
+// LimitsRolloutStatusFromConfigMap returns the state of the limits rollout held by the canary limits ConfigMap
+func LimitsRolloutStatusFromConfigMap(canaryConfigMap *v1.ConfigMap) (*limitadorv1alpha1.LimitsRolloutStatus, error) {
+	if canaryConfigMap == nil {
+		return nil, nil
+	}
+
+	rolloutJSON, ok := canaryConfigMap.GetAnnotations()[LimitsRolloutAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	rollout := &limitadorv1alpha1.LimitsRolloutStatus{}
+	if err := json.Unmarshal([]byte(rolloutJSON), rollout); err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+// LimitsCanarySelectorLabels select the canary pods of a limits rollout. The canary pods do not have the
+// limitador-resource label, hence are not selected by the objects selecting the limitador pods:
+// the Deployment, the Service, the PodDisruptionBudget, the NetworkPolicy and the monitors.
+func LimitsCanarySelectorLabels(limitadorObj *limitadorv1alpha1.Limitador) map[string]string {
+	return map[string]string{
+		helpers.LabelKeyApp: helpers.LimitadorAppName,
+		LimitsCanaryLabel:   limitadorObj.Name,
+	}
+}
+
+// LimitsCanaryDeployment returns the deployment of the canary pods, loading the limits of the canary limits ConfigMap.
+// The canary pods get the traffic of the Service through the canary EndpointSlices.
+// A nil rollout status, or a rolled back one, tags the deployment to be deleted.
+func LimitsCanaryDeployment(limitadorObj *limitadorv1alpha1.Limitador, deploymentOptions DeploymentOptions, rollout *limitadorv1alpha1.LimitsRolloutStatus) *appsv1.Deployment {
+	deployment := Deployment(limitadorObj, deploymentOptions)
+	deployment.Name = LimitsCanaryDeploymentName(limitadorObj)
+
+	if !limitsRolloutInProgress(limitadorObj, rollout) {
+		helpers.TagObjectToDelete(deployment)
+		return deployment
+	}
+
+	deployment.Spec.Replicas = ptr.To(limitadorObj.Spec.LimitsRollout.GetCanaryReplicas())
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: LimitsCanarySelectorLabels(limitadorObj)}
+	delete(deployment.Spec.Template.Labels, helpers.LabelKeyLimitadorResource)
+	helpers.MergeMapStringString(&deployment.Spec.Template.Labels, LimitsCanarySelectorLabels(limitadorObj))
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[PodAnnotationLimitsConfigHash] = rollout.ConfigHash
+
+	// The volumes are shared with the deployment options
+	volumes := make([]v1.Volume, 0, len(deployment.Spec.Template.Spec.Volumes))
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		volume := *volume.DeepCopy()
+		if volume.Name == LimitsCMVolumeName && volume.ConfigMap != nil {
+			volume.ConfigMap.Name = LimitsCanaryConfigMapName(limitadorObj)
+		}
+		volumes = append(volumes, volume)
+	}
+	deployment.Spec.Template.Spec.Volumes = volumes
+
+	return deployment
+}
+
+func LimitsCanaryEndpointSliceName(limitadorObj *limitadorv1alpha1.Limitador, addressType discoveryv1.AddressType) string {
+	return fmt.Sprintf("limitador-%s-limits-canary-%s", limitadorObj.Name, strings.ToLower(string(addressType)))
+}
+
+// LimitsCanaryEndpointSlices return the EndpointSlices adding the canary pods to the endpoints of the Service,
+// one per IP family. They are managed by the operator, not by the EndpointSlice controller, as the canary
+// pods are not selected by the Service. An EndpointSlice without endpoints, or while no rollout is in progress,
+// is tagged to be deleted.
+func LimitsCanaryEndpointSlices(limitadorObj *limitadorv1alpha1.Limitador, canaryPods []v1.Pod, rollout *limitadorv1alpha1.LimitsRolloutStatus) []*discoveryv1.EndpointSlice {
+	endpointSlices := make([]*discoveryv1.EndpointSlice, 0, 2)
+	for _, addressType := range []discoveryv1.AddressType{discoveryv1.AddressTypeIPv4, discoveryv1.AddressTypeIPv6} {
+		endpointSlice := &discoveryv1.EndpointSlice{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "EndpointSlice",
+				APIVersion: "discovery.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      LimitsCanaryEndpointSliceName(limitadorObj, addressType),
+				Namespace: limitadorObj.Namespace,
+				Labels:    Labels(limitadorObj),
+			},
+			AddressType: addressType,
+			Ports: []discoveryv1.EndpointPort{
+				{Name: ptr.To("http"), Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(limitadorObj.HTTPPort())},
+				{Name: ptr.To("grpc"), Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(limitadorObj.GRPCPort())},
+			},
+		}
+		endpointSlice.Labels[discoveryv1.LabelServiceName] = ServiceName(limitadorObj)
+		endpointSlice.Labels[discoveryv1.LabelManagedBy] = OperatorAppName
+
+		if limitsRolloutInProgress(limitadorObj, rollout) {
+			endpointSlice.Endpoints = canaryEndpoints(canaryPods, addressType)
+		}
+		if len(endpointSlice.Endpoints) == 0 {
+			helpers.TagObjectToDelete(endpointSlice)
+		}
+
+		endpointSlices = append(endpointSlices, endpointSlice)
+	}
+
+	return endpointSlices
+}
+
+// canaryEndpoints returns the endpoints of the canary pods having an IP of the address type
+func canaryEndpoints(canaryPods []v1.Pod, addressType discoveryv1.AddressType) []discoveryv1.Endpoint {
+	var endpoints []discoveryv1.Endpoint
+	for idx := range canaryPods {
+		pod := &canaryPods[idx]
+		podIP := podIPOfType(pod, addressType)
+		if podIP == "" {
+			continue
+		}
+
+		serving := podReady(pod)
+		terminating := pod.DeletionTimestamp != nil
+		endpoints = append(endpoints, discoveryv1.Endpoint{
+			Addresses: []string{podIP},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       ptr.To(serving && !terminating),
+				Serving:     ptr.To(serving),
+				Terminating: ptr.To(terminating),
+			},
+			NodeName: ptr.To(pod.Spec.NodeName),
+			TargetRef: &v1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+		})
+	}
+
+	return endpoints
+}
+
+func podIPOfType(pod *v1.Pod, addressType discoveryv1.AddressType) string {
+	for _, podIP := range pod.Status.PodIPs {
+		ip := net.ParseIP(podIP.IP)
+		if ip == nil {
+			continue
+		}
+		if (ip.To4() != nil) == (addressType == discoveryv1.AddressTypeIPv4) {
+			return podIP.IP
+		}
+	}
+
+	return ""
+}
+
+func podReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func LimitsCanaryNetworkPolicyName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-%s-limits-canary", limitadorObj.Name)
+}
+
+// LimitsCanaryNetworkPolicy restricts the traffic of the canary pods as the NetworkPolicy of the limitador pods.
+// It is tagged to be deleted while no rollout is in progress, or when the network policy is not enabled in the spec.
+func LimitsCanaryNetworkPolicy(limitadorObj *limitadorv1alpha1.Limitador, egressRules []networkingv1.NetworkPolicyEgressRule, rollout *limitadorv1alpha1.LimitsRolloutStatus) *networkingv1.NetworkPolicy {
+	networkPolicy := NetworkPolicy(limitadorObj, egressRules)
+	networkPolicy.Name = LimitsCanaryNetworkPolicyName(limitadorObj)
+
+	if !limitsRolloutInProgress(limitadorObj, rollout) {
+		helpers.TagObjectToDelete(networkPolicy)
+		return networkPolicy
+	}
+
+	networkPolicy.Spec.PodSelector = metav1.LabelSelector{MatchLabels: LimitsCanarySelectorLabels(limitadorObj)}
+
+	return networkPolicy
+}
+
+// limitsRolloutInProgress tells whether the canary pods are running, i.e. the rollout is not rolled back
+func limitsRolloutInProgress(limitadorObj *limitadorv1alpha1.Limitador, rollout *limitadorv1alpha1.LimitsRolloutStatus) bool {
+	return limitadorObj.Spec.LimitsRollout != nil && rollout != nil && rollout.Phase != limitadorv1alpha1.LimitsRolloutPhaseRolledBack
+}
+
+// NextLimitsRolloutStatus returns the state of the rollout of the desired limits config after this reconciliation,
+// out of the current state and the canary deployment, and the time to check the rollout again.
+// A nil state means the desired limits config is to be loaded by every pod.
+//
+// The canary pods have the bake time to get ready. Once ready, they must stay ready for the bake time.
+// Otherwise, the rollout is rolled back and the limits config is not loaded by the other pods.
+func NextLimitsRolloutStatus(limitadorObj *limitadorv1alpha1.Limitador, limitsConfigMap *v1.ConfigMap, current *limitadorv1alpha1.LimitsRolloutStatus, canaryDeployment *appsv1.Deployment, now time.Time) (*limitadorv1alpha1.LimitsRolloutStatus, time.Duration) {
+	bakeTime := limitadorObj.Spec.LimitsRollout.GetBakeTime()
+	canaryReplicas := limitadorObj.Spec.LimitsRollout.GetCanaryReplicas()
+	configHash := LimitsConfigHash(limitsConfigMap)
+
+	if current == nil || current.ConfigHash != configHash {
+		return &limitadorv1alpha1.LimitsRolloutStatus{
+			Phase:      limitadorv1alpha1.LimitsRolloutPhaseCanary,
+			ConfigHash: configHash,
+			StartTime:  metav1.NewTime(now),
+		}, bakeTime
+	}
+
+	next := current.DeepCopy()
+	ready := canaryDeploymentReady(canaryDeployment, configHash, canaryReplicas)
+
+	switch current.Phase {
+	case limitadorv1alpha1.LimitsRolloutPhaseCanary:
+		if ready {
+			next.Phase = limitadorv1alpha1.LimitsRolloutPhaseBaking
+			next.BakeStartTime = ptr.To(metav1.NewTime(now))
+			return next, bakeTime
+		}
+		if deadline := current.StartTime.Add(bakeTime); now.Before(deadline) {
+			return next, deadline.Sub(now)
+		}
+		next.Phase = limitadorv1alpha1.LimitsRolloutPhaseRolledBack
+		next.Message = fmt.Sprintf("canary pods not ready within %s: %s", bakeTime, canaryReadiness(canaryDeployment, canaryReplicas))
+		return next, 0
+	case limitadorv1alpha1.LimitsRolloutPhaseBaking:
+		if !ready {
+			next.Phase = limitadorv1alpha1.LimitsRolloutPhaseRolledBack
+			next.Message = fmt.Sprintf("canary pods readiness dropped while baking: %s", canaryReadiness(canaryDeployment, canaryReplicas))
+			return next, 0
+		}
+		if deadline := ptr.Deref(current.BakeStartTime, current.StartTime).Add(bakeTime); now.Before(deadline) {
+			return next, deadline.Sub(now)
+		}
+		return nil, 0
+	default:
+		// Rolled back, until the limits config changes
+		return next, 0
+	}
+}
+
+// canaryDeploymentReady tells whether every canary pod runs the limits config rolled out and is ready
+func canaryDeploymentReady(deployment *appsv1.Deployment, configHash string, replicas int32) bool {
+	if deployment == nil || deployment.Spec.Template.Annotations[PodAnnotationLimitsConfigHash] != configHash {
+		return false
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas
+}
+
+func canaryReadiness(deployment *appsv1.Deployment, replicas int32) string {
+	var ready int32
+	if deployment != nil {
+		ready = deployment.Status.ReadyReplicas
+	}
+
+	return fmt.Sprintf("%d/%d ready", ready, replicas)
+}
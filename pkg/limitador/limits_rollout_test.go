@@ -0,0 +1,272 @@
+package limitador
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+func newTestLimitsRolloutLimitadorObj() *limitadorv1alpha1.Limitador {
+	limObj := newTestLimitadorObj("some-name", "some-ns", []limitadorv1alpha1.RateLimit{
+		{Namespace: "some-namespace", MaxValue: 10, Seconds: 60},
+	})
+	limObj.Spec.LimitsRollout = &limitadorv1alpha1.LimitsRollout{
+		CanaryReplicas: ptr.To(int32(2)),
+		BakeTime:       &metav1.Duration{Duration: 10 * time.Minute},
+	}
+	return limObj
+}
+
+func TestNextLimitsRolloutStatus(t *testing.T) {
+	limObj := newTestLimitsRolloutLimitadorObj()
+	limitsConfigMap, err := LimitsConfigMap(limObj, nil)
+	assert.NilError(t, err)
+	configHash := LimitsConfigHash(limitsConfigMap)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	canaryDeployment := func(ready int32) *appsv1.Deployment {
+		deployment := LimitsCanaryDeployment(limObj, DeploymentOptions{}, &limitadorv1alpha1.LimitsRolloutStatus{ConfigHash: configHash})
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: ready}
+		return deployment
+	}
+
+	rolloutStatus := func(phase limitadorv1alpha1.LimitsRolloutPhase) *limitadorv1alpha1.LimitsRolloutStatus {
+		rollout := &limitadorv1alpha1.LimitsRolloutStatus{Phase: phase, ConfigHash: configHash, StartTime: metav1.NewTime(start)}
+		if phase == limitadorv1alpha1.LimitsRolloutPhaseBaking {
+			rollout.BakeStartTime = ptr.To(metav1.NewTime(start.Add(time.Minute)))
+		}
+		return rollout
+	}
+
+	t.Run("new limits config starts the canary", func(subT *testing.T) {
+		previous := rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseRolledBack)
+		previous.ConfigHash = "previous"
+
+		for _, current := range []*limitadorv1alpha1.LimitsRolloutStatus{nil, previous} {
+			next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, current, nil, start)
+			assert.Assert(subT, next != nil)
+			assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseCanary)
+			assert.Equal(subT, next.ConfigHash, configHash)
+			assert.Assert(subT, next.StartTime.Time.Equal(start))
+			assert.Equal(subT, requeueAfter, 10*time.Minute)
+		}
+	})
+
+	t.Run("ready canary pods start baking", func(subT *testing.T) {
+		now := start.Add(time.Minute)
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseCanary), canaryDeployment(2), now)
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseBaking)
+		assert.Assert(subT, next.BakeStartTime != nil && next.BakeStartTime.Time.Equal(now))
+		assert.Equal(subT, requeueAfter, 10*time.Minute)
+	})
+
+	t.Run("canary pods getting ready", func(subT *testing.T) {
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseCanary), canaryDeployment(1), start.Add(4*time.Minute))
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseCanary)
+		assert.Equal(subT, requeueAfter, 6*time.Minute)
+	})
+
+	t.Run("canary pods not ready within the bake time roll back", func(subT *testing.T) {
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseCanary), canaryDeployment(1), start.Add(10*time.Minute))
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseRolledBack)
+		assert.Equal(subT, next.Message, "canary pods not ready within 10m0s: 1/2 ready")
+		assert.Equal(subT, requeueAfter, time.Duration(0))
+	})
+
+	t.Run("canary pods of a previous limits config are not ready", func(subT *testing.T) {
+		deployment := canaryDeployment(2)
+		deployment.Spec.Template.Annotations[PodAnnotationLimitsConfigHash] = "previous"
+		next, _ := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseCanary), deployment, start.Add(time.Minute))
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseCanary)
+	})
+
+	t.Run("readiness dropping while baking rolls back", func(subT *testing.T) {
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseBaking), canaryDeployment(1), start.Add(5*time.Minute))
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseRolledBack)
+		assert.Equal(subT, next.Message, "canary pods readiness dropped while baking: 1/2 ready")
+		assert.Equal(subT, requeueAfter, time.Duration(0))
+	})
+
+	t.Run("baking", func(subT *testing.T) {
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseBaking), canaryDeployment(2), start.Add(5*time.Minute))
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseBaking)
+		assert.Equal(subT, requeueAfter, 6*time.Minute)
+	})
+
+	t.Run("baked limits config is promoted", func(subT *testing.T) {
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseBaking), canaryDeployment(2), start.Add(11*time.Minute))
+		assert.Assert(subT, next == nil)
+		assert.Equal(subT, requeueAfter, time.Duration(0))
+	})
+
+	t.Run("rolled back limits config is held", func(subT *testing.T) {
+		next, requeueAfter := NextLimitsRolloutStatus(limObj, limitsConfigMap, rolloutStatus(limitadorv1alpha1.LimitsRolloutPhaseRolledBack), nil, start.Add(time.Hour))
+		assert.Equal(subT, next.Phase, limitadorv1alpha1.LimitsRolloutPhaseRolledBack)
+		assert.Equal(subT, requeueAfter, time.Duration(0))
+	})
+}
+
+func TestLimitsCanaryDeployment(t *testing.T) {
+	limObj := newTestLimitsRolloutLimitadorObj()
+	deploymentOptions := DeploymentOptions{Volumes: DeploymentVolumes(limObj, DeploymentStorageOptions{})}
+	rollout := &limitadorv1alpha1.LimitsRolloutStatus{Phase: limitadorv1alpha1.LimitsRolloutPhaseCanary, ConfigHash: "some-hash"}
+
+	t.Run("canary pods load the canary limits ConfigMap", func(subT *testing.T) {
+		deployment := LimitsCanaryDeployment(limObj, deploymentOptions, rollout)
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(deployment))
+		assert.Equal(subT, deployment.Name, "limitador-some-name-limits-canary")
+		assert.Equal(subT, *deployment.Spec.Replicas, int32(2))
+		assert.DeepEqual(subT, deployment.Spec.Selector.MatchLabels, LimitsCanarySelectorLabels(limObj))
+		assert.Equal(subT, deployment.Spec.Template.Labels[LimitsCanaryLabel], "some-name")
+		assert.Equal(subT, deployment.Spec.Template.Annotations[PodAnnotationLimitsConfigHash], "some-hash")
+		assert.Equal(subT, deployment.Spec.Template.Spec.Volumes[0].ConfigMap.Name, "limitador-limits-config-some-name-canary")
+		// the limitador deployment keeps the limits ConfigMap
+		assert.Equal(subT, deploymentOptions.Volumes[0].ConfigMap.Name, LimitsConfigMapName(limObj))
+	})
+
+	t.Run("canary pods are not selected by the limitador objects", func(subT *testing.T) {
+		deployment := LimitsCanaryDeployment(limObj, deploymentOptions, rollout)
+		limitadorSelector := labels.SelectorFromSet(SelectorLabels(limObj))
+		canarySelector := labels.SelectorFromSet(LimitsCanarySelectorLabels(limObj))
+		assert.Assert(subT, !limitadorSelector.Matches(labels.Set(deployment.Spec.Template.Labels)))
+		assert.Assert(subT, canarySelector.Matches(labels.Set(deployment.Spec.Template.Labels)))
+		assert.Assert(subT, !canarySelector.Matches(labels.Set(Deployment(limObj, deploymentOptions).Spec.Template.Labels)))
+	})
+
+	t.Run("no rollout in progress", func(subT *testing.T) {
+		rolledBack := rollout.DeepCopy()
+		rolledBack.Phase = limitadorv1alpha1.LimitsRolloutPhaseRolledBack
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(LimitsCanaryDeployment(limObj, deploymentOptions, nil)))
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(LimitsCanaryDeployment(limObj, deploymentOptions, rolledBack)))
+	})
+}
+
+func TestLimitsCanaryConfigMap(t *testing.T) {
+	limObj := newTestLimitsRolloutLimitadorObj()
+	limitsConfigMap, err := LimitsConfigMap(limObj, nil)
+	assert.NilError(t, err)
+
+	t.Run("rollout state round trip", func(subT *testing.T) {
+		rollout := &limitadorv1alpha1.LimitsRolloutStatus{
+			Phase:      limitadorv1alpha1.LimitsRolloutPhaseCanary,
+			ConfigHash: LimitsConfigHash(limitsConfigMap),
+			StartTime:  metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		cm, err := LimitsCanaryConfigMap(limObj, limitsConfigMap, rollout)
+		assert.NilError(subT, err)
+		assert.Equal(subT, cm.Name, "limitador-limits-config-some-name-canary")
+		assert.Equal(subT, cm.Data[LimitadorConfigFileName], limitsConfigMap.Data[LimitadorConfigFileName])
+
+		current, err := LimitsRolloutStatusFromConfigMap(cm)
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, current, rollout)
+	})
+
+	t.Run("no rollout in progress", func(subT *testing.T) {
+		cm, err := LimitsCanaryConfigMap(limObj, limitsConfigMap, nil)
+		assert.NilError(subT, err)
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(cm))
+
+		current, err := LimitsRolloutStatusFromConfigMap(nil)
+		assert.NilError(subT, err)
+		assert.Assert(subT, current == nil)
+
+		current, err = LimitsRolloutStatusFromConfigMap(&v1.ConfigMap{})
+		assert.NilError(subT, err)
+		assert.Assert(subT, current == nil)
+	})
+}
+
+func TestLimitsCanaryEndpointSlices(t *testing.T) {
+	limObj := newTestLimitsRolloutLimitadorObj()
+	rollout := &limitadorv1alpha1.LimitsRolloutStatus{Phase: limitadorv1alpha1.LimitsRolloutPhaseBaking, ConfigHash: "some-hash"}
+
+	canaryPod := func(name string, ready bool, podIPs ...string) v1.Pod {
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-ns"},
+			Spec:       v1.PodSpec{NodeName: "some-node"},
+		}
+		for _, podIP := range podIPs {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, v1.PodIP{IP: podIP})
+		}
+		status := v1.ConditionFalse
+		if ready {
+			status = v1.ConditionTrue
+		}
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+		return pod
+	}
+
+	t.Run("canary pods are endpoints of the limitador service", func(subT *testing.T) {
+		pods := []v1.Pod{canaryPod("ready", true, "10.0.0.1"), canaryPod("not-ready", false, "10.0.0.2"), canaryPod("pending", false)}
+		endpointSlices := LimitsCanaryEndpointSlices(limObj, pods, rollout)
+		assert.Equal(subT, len(endpointSlices), 2)
+
+		ipv4 := endpointSlices[0]
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(ipv4))
+		assert.Equal(subT, ipv4.Name, "limitador-some-name-limits-canary-ipv4")
+		assert.Equal(subT, ipv4.AddressType, discoveryv1.AddressTypeIPv4)
+		assert.Equal(subT, ipv4.Labels[discoveryv1.LabelServiceName], ServiceName(limObj))
+		assert.Equal(subT, ipv4.Labels[discoveryv1.LabelManagedBy], OperatorAppName)
+		assert.Equal(subT, len(ipv4.Endpoints), 2)
+		assert.DeepEqual(subT, ipv4.Endpoints[0].Addresses, []string{"10.0.0.1"})
+		assert.Equal(subT, *ipv4.Endpoints[0].Conditions.Ready, true)
+		assert.Equal(subT, ipv4.Endpoints[0].TargetRef.Name, "ready")
+		assert.Equal(subT, *ipv4.Endpoints[1].Conditions.Ready, false)
+		assert.Equal(subT, *ipv4.Ports[0].Port, limObj.HTTPPort())
+		assert.Equal(subT, *ipv4.Ports[1].Port, limObj.GRPCPort())
+
+		// no IPv6 canary pod
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(endpointSlices[1]))
+	})
+
+	t.Run("dual stack canary pods", func(subT *testing.T) {
+		endpointSlices := LimitsCanaryEndpointSlices(limObj, []v1.Pod{canaryPod("ready", true, "10.0.0.1", "fd00::1")}, rollout)
+		assert.DeepEqual(subT, endpointSlices[0].Endpoints[0].Addresses, []string{"10.0.0.1"})
+		assert.Equal(subT, endpointSlices[1].Name, "limitador-some-name-limits-canary-ipv6")
+		assert.DeepEqual(subT, endpointSlices[1].Endpoints[0].Addresses, []string{"fd00::1"})
+	})
+
+	t.Run("no rollout in progress", func(subT *testing.T) {
+		rolledBack := rollout.DeepCopy()
+		rolledBack.Phase = limitadorv1alpha1.LimitsRolloutPhaseRolledBack
+		pods := []v1.Pod{canaryPod("ready", true, "10.0.0.1")}
+		for _, current := range []*limitadorv1alpha1.LimitsRolloutStatus{nil, rolledBack} {
+			for _, endpointSlice := range LimitsCanaryEndpointSlices(limObj, pods, current) {
+				assert.Assert(subT, helpers.IsObjectTaggedToDelete(endpointSlice))
+			}
+		}
+	})
+}
+
+func TestLimitsCanaryNetworkPolicy(t *testing.T) {
+	limObj := newTestLimitsRolloutLimitadorObj()
+	rollout := &limitadorv1alpha1.LimitsRolloutStatus{Phase: limitadorv1alpha1.LimitsRolloutPhaseCanary, ConfigHash: "some-hash"}
+
+	t.Run("network policy not enabled", func(subT *testing.T) {
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(LimitsCanaryNetworkPolicy(limObj, nil, rollout)))
+	})
+
+	t.Run("canary pods are restricted as the limitador pods", func(subT *testing.T) {
+		limObj := limObj.DeepCopy()
+		limObj.Spec.NetworkPolicy = &limitadorv1alpha1.NetworkPolicySpec{}
+		networkPolicy := LimitsCanaryNetworkPolicy(limObj, nil, rollout)
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(networkPolicy))
+		assert.Equal(subT, networkPolicy.Name, "limitador-some-name-limits-canary")
+		assert.DeepEqual(subT, networkPolicy.Spec.PodSelector.MatchLabels, LimitsCanarySelectorLabels(limObj))
+		assert.DeepEqual(subT, networkPolicy.Spec.Ingress, NetworkPolicy(limObj, nil).Spec.Ingress)
+
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(LimitsCanaryNetworkPolicy(limObj, nil, nil)))
+	})
+}
@@ -0,0 +1,160 @@
+package limitador
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+// SourcesLimits is the outcome of loading the limits of the spec.limitsFrom sources of a Limitador object
+type SourcesLimits struct {
+	// Limits of the valid sources, in the order of the references
+	Limits []limitadorv1alpha1.RateLimit
+	// Errors of the sources that cannot be loaded
+	Errors field.ErrorList
+}
+
+// LimitsFromSources reads the limits of the ConfigMap and Secret keys referenced by spec.limitsFrom.
+// A source is not loaded when it holds an invalid limit or a limit name already taken by an inline limit
+// or by a previous source. A missing source is an error unless its reference is optional.
+// The limits are incomplete while there are errors, they must not be rendered.
+func LimitsFromSources(ctx context.Context, cl client.Reader, limObj *limitadorv1alpha1.Limitador) (SourcesLimits, error) {
+	result := SourcesLimits{
+		Limits: []limitadorv1alpha1.RateLimit{},
+		Errors: field.ErrorList{},
+	}
+
+	names := map[string]string{}
+	for idx, limit := range limObj.Limits() {
+		if limit.Name != "" {
+			names[limit.Name] = field.NewPath("spec", "limits").Index(idx).String()
+		}
+	}
+
+	for idx, source := range limObj.Spec.LimitsFrom {
+		sourcePath := field.NewPath("spec", "limitsFrom").Index(idx)
+
+		data, found, err := limitsSourceData(ctx, cl, limObj.Namespace, source)
+		if err != nil {
+			return result, err
+		}
+		if !found {
+			if !limitsSourceOptional(source) {
+				result.Errors = append(result.Errors, field.NotFound(sourcePath, limitsSourceDescription(source)))
+			}
+			continue
+		}
+
+		var limits []limitadorv1alpha1.RateLimit
+		if err := yaml.UnmarshalStrict(data, &limits); err != nil {
+			result.Errors = append(result.Errors, field.Invalid(sourcePath, limitsSourceDescription(source), err.Error()))
+			continue
+		}
+
+		limitsPath := sourcePath.Child("limits")
+		errs := limitadorv1alpha1.ValidateRateLimits(limits, limitsPath)
+		for limitIdx, limit := range limits {
+			if limit.Name == "" {
+				continue
+			}
+			if owner, ok := names[limit.Name]; ok {
+				errs = append(errs, field.Duplicate(limitsPath.Index(limitIdx).Child("name"), fmt.Sprintf("%s, already used by %s", limit.Name, owner)))
+			}
+		}
+		if len(errs) > 0 {
+			result.Errors = append(result.Errors, errs...)
+			continue
+		}
+
+		for limitIdx, limit := range limits {
+			if limit.Name != "" {
+				names[limit.Name] = limitsPath.Index(limitIdx).String()
+			}
+		}
+		result.Limits = append(result.Limits, limits...)
+	}
+
+	return result, nil
+}
+
+// LimitsSourceConfigMapNames returns the names of the ConfigMaps referenced by spec.limitsFrom
+func LimitsSourceConfigMapNames(limObj *limitadorv1alpha1.Limitador) []string {
+	names := []string{}
+	for _, source := range limObj.Spec.LimitsFrom {
+		if source.ConfigMapKeyRef != nil {
+			names = append(names, source.ConfigMapKeyRef.Name)
+		}
+	}
+
+	return names
+}
+
+// LimitsSourceSecretNames returns the names of the Secrets referenced by spec.limitsFrom
+func LimitsSourceSecretNames(limObj *limitadorv1alpha1.Limitador) []string {
+	names := []string{}
+	for _, source := range limObj.Spec.LimitsFrom {
+		if source.SecretKeyRef != nil {
+			names = append(names, source.SecretKeyRef.Name)
+		}
+	}
+
+	return names
+}
+
+// limitsSourceData returns the content of the key referenced by the source, and whether it was found
+func limitsSourceData(ctx context.Context, cl client.Reader, namespace string, source limitadorv1alpha1.LimitsSource) ([]byte, bool, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		cm := &v1.ConfigMap{}
+		if err := cl.Get(ctx, client.ObjectKey{Name: source.ConfigMapKeyRef.Name, Namespace: namespace}, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		data, ok := cm.Data[source.ConfigMapKeyRef.Key]
+		return []byte(data), ok, nil
+	case source.SecretKeyRef != nil:
+		secret := &v1.Secret{}
+		if err := cl.Get(ctx, client.ObjectKey{Name: source.SecretKeyRef.Name, Namespace: namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		data, ok := secret.Data[source.SecretKeyRef.Key]
+		return data, ok, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func limitsSourceOptional(source limitadorv1alpha1.LimitsSource) bool {
+	if source.ConfigMapKeyRef != nil {
+		return ptr.Deref(source.ConfigMapKeyRef.Optional, false)
+	}
+	if source.SecretKeyRef != nil {
+		return ptr.Deref(source.SecretKeyRef.Optional, false)
+	}
+
+	return false
+}
+
+func limitsSourceDescription(source limitadorv1alpha1.LimitsSource) string {
+	if source.ConfigMapKeyRef != nil {
+		return fmt.Sprintf("key %s of configmap %s", source.ConfigMapKeyRef.Key, source.ConfigMapKeyRef.Name)
+	}
+	if source.SecretKeyRef != nil {
+		return fmt.Sprintf("key %s of secret %s", source.SecretKeyRef.Key, source.SecretKeyRef.Name)
+	}
+
+	return ""
+}
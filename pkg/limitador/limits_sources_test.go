@@ -0,0 +1,106 @@
+package limitador
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+func TestLimitsFromSources(t *testing.T) {
+	ctx := context.Background()
+
+	configMapSource := func(name, key string) limitadorv1alpha1.LimitsSource {
+		return limitadorv1alpha1.LimitsSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}}
+	}
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "generated", Namespace: "some-ns"},
+		Data: map[string]string{
+			"limits.yaml":  "- namespace: generated\n  max_value: 10\n  seconds: 60\n  conditions: []\n  variables: []\n  name: generated\n",
+			"invalid.yaml": "- namespace: invalid\n  max_value: 0\n  seconds: 60\n  conditions: []\n  variables: []\n",
+			"typo.yaml":    "- namespace: typo\n  maxValue: 10\n  seconds: 60\n",
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: "some-ns"},
+		Data: map[string][]byte{
+			"limits.yaml": []byte("- namespace: private\n  max_value: 5\n  seconds: 1\n  conditions: []\n  variables: []\n"),
+		},
+	}
+	cl := fake.NewClientBuilder().WithObjects([]client.Object{configMap, secret}...).Build()
+
+	t.Run("limits are loaded in the order of the references", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.LimitsFrom = []limitadorv1alpha1.LimitsSource{
+			{SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "private"}, Key: "limits.yaml"}},
+			configMapSource("generated", "limits.yaml"),
+		}
+
+		result, err := LimitsFromSources(ctx, cl, limObj)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(result.Errors) == 0)
+		assert.DeepEqual(subT, result.Limits, []limitadorv1alpha1.RateLimit{
+			{Namespace: "private", MaxValue: 5, Seconds: 1, Conditions: []string{}, Variables: []string{}},
+			{Namespace: "generated", MaxValue: 10, Seconds: 60, Conditions: []string{}, Variables: []string{}, Name: "generated"},
+		})
+	})
+
+	t.Run("missing sources", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		optional := configMapSource("generated", "missing.yaml")
+		optional.ConfigMapKeyRef.Optional = ptr.To(true)
+		limObj.Spec.LimitsFrom = []limitadorv1alpha1.LimitsSource{
+			configMapSource("missing", "limits.yaml"),
+			optional,
+		}
+
+		result, err := LimitsFromSources(ctx, cl, limObj)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(result.Limits) == 0)
+		assert.Assert(subT, len(result.Errors) == 1)
+		assert.Equal(subT, result.Errors[0].Error(), `spec.limitsFrom[0]: Not found: "key limits.yaml of configmap missing"`)
+	})
+
+	t.Run("invalid sources are not loaded", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.LimitsFrom = []limitadorv1alpha1.LimitsSource{
+			configMapSource("generated", "invalid.yaml"),
+			configMapSource("generated", "typo.yaml"),
+			configMapSource("generated", "limits.yaml"),
+		}
+
+		result, err := LimitsFromSources(ctx, cl, limObj)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(result.Limits) == 1)
+		assert.Equal(subT, result.Limits[0].Name, "generated")
+		assert.Assert(subT, len(result.Errors) == 2)
+		assert.Assert(subT, strings.HasPrefix(result.Errors[0].Error(), "spec.limitsFrom[0].limits[0].max_value: Invalid value"))
+		assert.Assert(subT, strings.Contains(result.Errors[1].Error(), `unknown field "maxValue"`))
+	})
+
+	t.Run("limit names already taken", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", []limitadorv1alpha1.RateLimit{
+			{Namespace: "inline", MaxValue: 1, Seconds: 1, Name: "generated"},
+		})
+		limObj.Spec.LimitsFrom = []limitadorv1alpha1.LimitsSource{configMapSource("generated", "limits.yaml")}
+
+		result, err := LimitsFromSources(ctx, cl, limObj)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(result.Limits) == 0)
+		assert.Assert(subT, len(result.Errors) == 1)
+		assert.Equal(subT, result.Errors[0].Error(),
+			`spec.limitsFrom[0].limits[0].name: Duplicate value: "generated, already used by spec.limits[0]"`)
+	})
+}
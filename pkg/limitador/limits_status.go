@@ -0,0 +1,89 @@
+package limitador
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+// LimitsStatus summarizes the limits of the limits ConfigMap and the ConfigMap resource version
+// acknowledged by the pods, out of the PodAnnotationConfigMapResourceVersion annotation.
+// The shadow limits are counted out of the mode of the limits, held by the LimitsConfigMapShadowAnnotation annotation.
+// Pods being deleted are ignored.
+func LimitsStatus(cm *v1.ConfigMap, pods []v1.Pod) (*limitadorv1alpha1.LimitsStatus, error) {
+	limits, err := ConfigMapLimits(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &limitadorv1alpha1.LimitsStatus{
+		Total:                    int32(len(limits)),
+		ConfigHash:               LimitsConfigHash(cm),
+		ConfigMapResourceVersion: cm.ResourceVersion,
+	}
+
+	if shadow, ok := cm.GetAnnotations()[LimitsConfigMapShadowAnnotation]; ok {
+		count, err := strconv.ParseInt(shadow, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", LimitsConfigMapShadowAnnotation, err)
+		}
+		status.Shadow = int32(count)
+	}
+
+	counts := map[string]int32{}
+	for _, limit := range limits {
+		counts[limit.Namespace]++
+	}
+	for namespace, count := range counts {
+		status.Namespaces = append(status.Namespaces, limitadorv1alpha1.NamespaceLimitsStatus{
+			Namespace: namespace,
+			Count:     count,
+		})
+	}
+	sort.Slice(status.Namespaces, func(i, j int) bool {
+		return status.Namespaces[i].Namespace < status.Namespaces[j].Namespace
+	})
+
+	versions := map[string]struct{}{}
+	for idx := range pods {
+		if pods[idx].GetDeletionTimestamp() != nil {
+			continue
+		}
+		version := pods[idx].GetAnnotations()[limitadorv1alpha1.PodAnnotationConfigMapResourceVersion]
+		versions[version] = struct{}{}
+		if version == cm.ResourceVersion {
+			status.AcknowledgedReplicas++
+		}
+	}
+
+	if len(versions) == 1 {
+		for version := range versions {
+			status.AcknowledgedResourceVersion = version
+		}
+	}
+
+	return status, nil
+}
+
+// LimitsConfigHash returns the SHA-256 hash of the limits config file of the limits ConfigMap
+func LimitsConfigHash(cm *v1.ConfigMap) string {
+	hash := sha256.Sum256([]byte(cm.Data[LimitadorConfigFileName]))
+	return hex.EncodeToString(hash[:])
+}
+
+// ConfigMapLimits returns the limits of the limits ConfigMap
+func ConfigMapLimits(cm *v1.ConfigMap) ([]limitadorv1alpha1.RateLimit, error) {
+	var limits []limitadorv1alpha1.RateLimit
+	if err := yaml.Unmarshal([]byte(cm.Data[LimitadorConfigFileName]), &limits); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
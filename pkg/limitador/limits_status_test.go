@@ -0,0 +1,107 @@
+package limitador
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+func newTestLimitsPod(name, version string) corev1.Pod {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if version != "" {
+		pod.Annotations = map[string]string{limitadorv1alpha1.PodAnnotationConfigMapResourceVersion: version}
+	}
+	return pod
+}
+
+func TestLimitsStatus(t *testing.T) {
+	limObj := newTestLimitadorObj("some-name", "some-ns", []limitadorv1alpha1.RateLimit{
+		{Namespace: "ns-b", MaxValue: 1, Seconds: 1},
+		{Namespace: "ns-a", MaxValue: 1, Seconds: 1},
+		{Namespace: "ns-b", MaxValue: 2, Seconds: 1},
+	})
+	cm, err := LimitsConfigMap(limObj, nil)
+	assert.NilError(t, err)
+	cm.ResourceVersion = "2"
+
+	t.Run("limits are counted per namespace", func(subT *testing.T) {
+		status, err := LimitsStatus(cm, nil)
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.Total, int32(3))
+		assert.DeepEqual(subT, status.Namespaces, []limitadorv1alpha1.NamespaceLimitsStatus{
+			{Namespace: "ns-a", Count: 1},
+			{Namespace: "ns-b", Count: 2},
+		})
+		assert.Equal(subT, status.ConfigMapResourceVersion, "2")
+		assert.Equal(subT, len(status.ConfigHash), 64)
+	})
+
+	t.Run("shadow limits are counted", func(subT *testing.T) {
+		shadowObj := limObj.DeepCopy()
+		shadowObj.Spec.Limits[1].Mode = limitadorv1alpha1.LimitModeShadow
+		shadowCM, err := LimitsConfigMap(shadowObj, nil)
+		assert.NilError(subT, err)
+
+		status, err := LimitsStatus(shadowCM, nil)
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.Total, int32(3))
+		assert.Equal(subT, status.Shadow, int32(1))
+	})
+
+	t.Run("shadow limits are counted out of their mode", func(subT *testing.T) {
+		// An enforced limit with the maximum value of the shadow limits
+		enforcedObj := limObj.DeepCopy()
+		enforcedObj.Spec.Limits[1].MaxValue = ShadowMaxValue
+		enforcedCM, err := LimitsConfigMap(enforcedObj, nil)
+		assert.NilError(subT, err)
+
+		status, err := LimitsStatus(enforcedCM, nil)
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.Shadow, int32(0))
+	})
+
+	t.Run("config hash changes with the limits", func(subT *testing.T) {
+		status, err := LimitsStatus(cm, nil)
+		assert.NilError(subT, err)
+
+		otherCM := cm.DeepCopy()
+		otherCM.Data[LimitadorConfigFileName] = "[]"
+		otherStatus, err := LimitsStatus(otherCM, nil)
+		assert.NilError(subT, err)
+		assert.Equal(subT, otherStatus.Total, int32(0))
+		assert.Assert(subT, otherStatus.Namespaces == nil)
+		assert.Assert(subT, status.ConfigHash != otherStatus.ConfigHash)
+	})
+
+	t.Run("version acknowledged by every pod", func(subT *testing.T) {
+		status, err := LimitsStatus(cm, []corev1.Pod{newTestLimitsPod("a", "2"), newTestLimitsPod("b", "2")})
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.AcknowledgedResourceVersion, "2")
+		assert.Equal(subT, status.AcknowledgedReplicas, int32(2))
+	})
+
+	t.Run("pods lagging behind", func(subT *testing.T) {
+		status, err := LimitsStatus(cm, []corev1.Pod{newTestLimitsPod("a", "2"), newTestLimitsPod("b", "1")})
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.AcknowledgedResourceVersion, "")
+		assert.Equal(subT, status.AcknowledgedReplicas, int32(1))
+
+		status, err = LimitsStatus(cm, []corev1.Pod{newTestLimitsPod("a", "1"), newTestLimitsPod("b", "")})
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.AcknowledgedResourceVersion, "")
+		assert.Equal(subT, status.AcknowledgedReplicas, int32(0))
+	})
+
+	t.Run("pods being deleted are ignored", func(subT *testing.T) {
+		deletedPod := newTestLimitsPod("b", "1")
+		deletedPod.DeletionTimestamp = &metav1.Time{}
+		status, err := LimitsStatus(cm, []corev1.Pod{newTestLimitsPod("a", "2"), deletedPod})
+		assert.NilError(subT, err)
+		assert.Equal(subT, status.AcknowledgedResourceVersion, "2")
+		assert.Equal(subT, status.AcknowledgedReplicas, int32(1))
+	})
+}
@@ -0,0 +1,68 @@
+package limitador
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitador/conditions"
+)
+
+// LimitsByNamespace groups the limits by limit namespace
+func LimitsByNamespace(limits []limitadorv1alpha1.RateLimit) map[string][]limitadorv1alpha1.RateLimit {
+	result := map[string][]limitadorv1alpha1.RateLimit{}
+	for _, limit := range limits {
+		result[limit.Namespace] = append(result[limit.Namespace], limit)
+	}
+	return result
+}
+
+// DiffLimits compares the expected limits with the limits loaded by limitador.
+// The order of the limits, and of their conditions and variables, does not matter. The conditions are normalized,
+// as limitador reports them in its own form.
+// It returns the expected limits not loaded and the loaded limits not expected.
+func DiffLimits(expected, loaded []limitadorv1alpha1.RateLimit) (missing, unexpected []limitadorv1alpha1.RateLimit) {
+	loadedKeys := map[string]int{}
+	for _, limit := range loaded {
+		loadedKeys[limitKey(limit)]++
+	}
+
+	for _, limit := range expected {
+		key := limitKey(limit)
+		if loadedKeys[key] > 0 {
+			loadedKeys[key]--
+			continue
+		}
+		missing = append(missing, limit)
+	}
+
+	for _, limit := range loaded {
+		key := limitKey(limit)
+		if loadedKeys[key] > 0 {
+			loadedKeys[key]--
+			unexpected = append(unexpected, limit)
+		}
+	}
+
+	return missing, unexpected
+}
+
+func limitKey(limit limitadorv1alpha1.RateLimit) string {
+	normalized := make([]string, 0, len(limit.Conditions))
+	for _, condition := range limit.Conditions {
+		normalized = append(normalized, conditions.Normalize(condition))
+	}
+	sort.Strings(normalized)
+	variables := slices.Clone(limit.Variables)
+	sort.Strings(variables)
+
+	return strings.Join([]string{
+		limit.Namespace,
+		limit.Name,
+		strings.Join([]string{strconv.Itoa(limit.MaxValue), strconv.Itoa(limit.Seconds)}, "/"),
+		strings.Join(normalized, "\x00"),
+		strings.Join(variables, "\x00"),
+	}, "\x01")
+}
@@ -0,0 +1,62 @@
+package limitador
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+func TestDiffLimits(t *testing.T) {
+	limitA := limitadorv1alpha1.RateLimit{
+		Namespace: "ns", MaxValue: 10, Seconds: 60,
+		Conditions: []string{"req.method == 'GET'", "req.path == '/'"},
+		Variables:  []string{"user_id", "org_id"},
+	}
+	limitB := limitadorv1alpha1.RateLimit{Namespace: "ns", MaxValue: 5, Seconds: 1, Name: "b"}
+
+	t.Run("same limits in any order", func(subT *testing.T) {
+		reordered := limitA
+		reordered.Conditions = []string{"req.path == '/'", "req.method == 'GET'"}
+		reordered.Variables = []string{"org_id", "user_id"}
+
+		missing, unexpected := DiffLimits([]limitadorv1alpha1.RateLimit{limitA, limitB}, []limitadorv1alpha1.RateLimit{limitB, reordered})
+		assert.Equal(subT, len(missing), 0)
+		assert.Equal(subT, len(unexpected), 0)
+	})
+
+	t.Run("conditions normalized by limitador", func(subT *testing.T) {
+		normalized := limitA
+		normalized.Conditions = []string{`req.path == "/"`, `req.method == "GET"`}
+
+		missing, unexpected := DiffLimits([]limitadorv1alpha1.RateLimit{limitA}, []limitadorv1alpha1.RateLimit{normalized})
+		assert.Equal(subT, len(missing), 0)
+		assert.Equal(subT, len(unexpected), 0)
+	})
+
+	t.Run("missing and unexpected limits", func(subT *testing.T) {
+		changed := limitB
+		changed.MaxValue = 6
+
+		missing, unexpected := DiffLimits([]limitadorv1alpha1.RateLimit{limitA, limitB}, []limitadorv1alpha1.RateLimit{changed})
+		assert.DeepEqual(subT, missing, []limitadorv1alpha1.RateLimit{limitA, limitB})
+		assert.DeepEqual(subT, unexpected, []limitadorv1alpha1.RateLimit{changed})
+	})
+
+	t.Run("duplicated limits are counted", func(subT *testing.T) {
+		missing, unexpected := DiffLimits([]limitadorv1alpha1.RateLimit{limitB, limitB}, []limitadorv1alpha1.RateLimit{limitB})
+		assert.DeepEqual(subT, missing, []limitadorv1alpha1.RateLimit{limitB})
+		assert.Equal(subT, len(unexpected), 0)
+	})
+}
+
+func TestLimitsByNamespace(t *testing.T) {
+	limits := []limitadorv1alpha1.RateLimit{
+		{Namespace: "ns-a", MaxValue: 1}, {Namespace: "ns-b", MaxValue: 2}, {Namespace: "ns-a", MaxValue: 3},
+	}
+	assert.DeepEqual(t, LimitsByNamespace(limits), map[string][]limitadorv1alpha1.RateLimit{
+		"ns-a": {limits[0], limits[2]},
+		"ns-b": {limits[1]},
+	})
+}
@@ -0,0 +1,257 @@
+package limitador
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+const (
+	ManagedRedisAppName   = "limitador-redis"
+	ManagedRedisPort      = 6379
+	ManagedRedisDataPath  = "/data"
+	ManagedRedisComponent = "redis"
+	ManagedRedisImage     = "docker.io/library/redis:7"
+)
+
+func ManagedRedisName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-%s-redis", limitadorObj.Name)
+}
+
+// ManagedRedisSelectorLabels selects the managed Redis pods. They must not be selected by the limitador
+// deployment and service selectors, hence the different app label.
+func ManagedRedisSelectorLabels(limitadorObj *limitadorv1alpha1.Limitador) map[string]string {
+	return map[string]string{
+		helpers.LabelKeyApp:               ManagedRedisAppName,
+		helpers.LabelKeyLimitadorResource: limitadorObj.Name,
+	}
+}
+
+// ManagedRedisLabels are the labels of the managed Redis objects. They keep the limitador app label,
+// so the objects are selected by the label filtered cache of the operator.
+func ManagedRedisLabels(limitadorObj *limitadorv1alpha1.Limitador) map[string]string {
+	labels := Labels(limitadorObj)
+	labels["app.kubernetes.io/component"] = ManagedRedisComponent
+	return labels
+}
+
+// ManagedRedisConfigSecretRef returns the secret holding the Redis URL for the redis storage,
+// either the one generated for the managed Redis or the one referenced in the spec
+func ManagedRedisConfigSecretRef(limitadorObj *limitadorv1alpha1.Limitador, redisObj limitadorv1alpha1.Redis) *v1.LocalObjectReference {
+	if redisObj.Managed != nil {
+		return &v1.LocalObjectReference{Name: ManagedRedisName(limitadorObj)}
+	}
+
+	return redisObj.ConfigSecretRef
+}
+
+func ManagedRedisSecret(limitadorObj *limitadorv1alpha1.Limitador) *v1.Secret {
+	secret := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedRedisName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    ManagedRedisLabels(limitadorObj),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+
+	if limitadorObj.ManagedRedis() == nil {
+		helpers.TagObjectToDelete(secret)
+		return secret
+	}
+
+	secret.StringData = map[string]string{
+		"URL": fmt.Sprintf("redis://%s.%s.svc:%d", ManagedRedisName(limitadorObj), limitadorObj.Namespace, ManagedRedisPort),
+	}
+
+	return secret
+}
+
+func ManagedRedisService(limitadorObj *limitadorv1alpha1.Limitador) *v1.Service {
+	service := &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedRedisName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    ManagedRedisLabels(limitadorObj),
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Name:       "redis",
+					Protocol:   v1.ProtocolTCP,
+					Port:       ManagedRedisPort,
+					TargetPort: intstr.FromString("redis"),
+				},
+			},
+			Selector: ManagedRedisSelectorLabels(limitadorObj),
+			Type:     v1.ServiceTypeClusterIP,
+		},
+	}
+
+	if limitadorObj.ManagedRedis() == nil {
+		helpers.TagObjectToDelete(service)
+	}
+
+	return service
+}
+
+func ManagedRedisPVC(limitadorObj *limitadorv1alpha1.Limitador) *v1.PersistentVolumeClaim {
+	pvc := &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedRedisName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    ManagedRedisLabels(limitadorObj),
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{
+					// Default value for resources
+					v1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
+	managed := limitadorObj.ManagedRedis()
+	if managed == nil {
+		helpers.TagObjectToDelete(pvc)
+		return pvc
+	}
+
+	pvc.Annotations = map[string]string{
+		helpers.RetainPolicyAnnotation: string(ptr.Deref(managed.RetainPolicy, limitadorv1alpha1.RetainPolicyDelete)),
+	}
+
+	if managed.PVC != nil {
+		pvc.Spec.StorageClassName = managed.PVC.StorageClassName
+		if managed.PVC.VolumeName != nil {
+			pvc.Spec.VolumeName = *managed.PVC.VolumeName
+		}
+
+		if managed.PVC.Resources != nil {
+			pvc.Spec.Resources = v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: managed.PVC.Resources.Requests,
+				},
+			}
+		}
+	}
+
+	return pvc
+}
+
+func ManagedRedisStatefulSet(limitadorObj *limitadorv1alpha1.Limitador) *appsv1.StatefulSet {
+	statefulSet := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagedRedisName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    ManagedRedisLabels(limitadorObj),
+		},
+	}
+
+	managed := limitadorObj.ManagedRedis()
+	if managed == nil {
+		helpers.TagObjectToDelete(statefulSet)
+		return statefulSet
+	}
+
+	image := ManagedRedisImage
+	if managed.Image != nil {
+		image = *managed.Image
+	}
+
+	resources := v1.ResourceRequirements{}
+	if managed.Resources != nil {
+		resources = *managed.Resources
+	}
+
+	podLabels := ManagedRedisLabels(limitadorObj)
+	helpers.MergeMapStringString(&podLabels, ManagedRedisSelectorLabels(limitadorObj))
+
+	statefulSet.Spec = appsv1.StatefulSetSpec{
+		Replicas:    ptr.To(int32(1)),
+		ServiceName: ManagedRedisName(limitadorObj),
+		Selector: &metav1.LabelSelector{
+			MatchLabels: ManagedRedisSelectorLabels(limitadorObj),
+		},
+		Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: podLabels,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name:    "redis",
+						Image:   image,
+						Command: []string{"redis-server"},
+						Args:    []string{"--appendonly", "yes", "--dir", ManagedRedisDataPath},
+						Ports: []v1.ContainerPort{
+							{
+								Name:          "redis",
+								ContainerPort: ManagedRedisPort,
+								Protocol:      v1.ProtocolTCP,
+							},
+						},
+						ReadinessProbe: &v1.Probe{
+							ProbeHandler: v1.ProbeHandler{
+								Exec: &v1.ExecAction{
+									Command: []string{"redis-cli", "ping"},
+								},
+							},
+							InitialDelaySeconds: 5,
+							TimeoutSeconds:      2,
+							PeriodSeconds:       10,
+							SuccessThreshold:    1,
+							FailureThreshold:    3,
+						},
+						Resources: resources,
+						VolumeMounts: []v1.VolumeMount{
+							{
+								Name:      "data",
+								MountPath: ManagedRedisDataPath,
+							},
+						},
+						ImagePullPolicy: v1.PullIfNotPresent,
+					},
+				},
+				Volumes: []v1.Volume{
+					{
+						Name: "data",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+								ClaimName: ManagedRedisName(limitadorObj),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return statefulSet
+}
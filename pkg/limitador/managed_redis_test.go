@@ -0,0 +1,102 @@
+package limitador
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+func newManagedRedisLimitadorObj() *limitadorv1alpha1.Limitador {
+	limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+	limObj.Spec.Storage = &limitadorv1alpha1.Storage{
+		Redis: &limitadorv1alpha1.Redis{
+			Managed: &limitadorv1alpha1.ManagedRedis{},
+		},
+	}
+	return limObj
+}
+
+func TestManagedRedis(t *testing.T) {
+	t.Run("objects are tagged to delete when redis is not managed", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(ManagedRedisSecret(limObj)))
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(ManagedRedisService(limObj)))
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(ManagedRedisPVC(limObj)))
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(ManagedRedisStatefulSet(limObj)))
+	})
+
+	t.Run("secret holds the service URL", func(subT *testing.T) {
+		secret := ManagedRedisSecret(newManagedRedisLimitadorObj())
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(secret))
+		assert.Equal(subT, secret.Name, "limitador-some-name-redis")
+		assert.Equal(subT, secret.StringData["URL"], "redis://limitador-some-name-redis.some-ns.svc:6379")
+	})
+
+	t.Run("config secret ref", func(subT *testing.T) {
+		limObj := newManagedRedisLimitadorObj()
+		assert.DeepEqual(subT,
+			ManagedRedisConfigSecretRef(limObj, *limObj.Spec.Storage.Redis),
+			&corev1.LocalObjectReference{Name: "limitador-some-name-redis"},
+		)
+
+		redisObj := limitadorv1alpha1.Redis{ConfigSecretRef: &corev1.LocalObjectReference{Name: "redisSecret"}}
+		assert.DeepEqual(subT,
+			ManagedRedisConfigSecretRef(limObj, redisObj),
+			&corev1.LocalObjectReference{Name: "redisSecret"},
+		)
+	})
+
+	t.Run("redis pods are not selected by limitador", func(subT *testing.T) {
+		limObj := newManagedRedisLimitadorObj()
+		statefulSet := ManagedRedisStatefulSet(limObj)
+		service := ManagedRedisService(limObj)
+		assert.DeepEqual(subT, statefulSet.Spec.Selector.MatchLabels, ManagedRedisSelectorLabels(limObj))
+		assert.DeepEqual(subT, service.Spec.Selector, ManagedRedisSelectorLabels(limObj))
+		assert.Assert(subT, statefulSet.Spec.Template.Labels[helpers.LabelKeyApp] != helpers.LimitadorAppName)
+		assert.Equal(subT, statefulSet.Labels[helpers.LabelKeyApp], helpers.LimitadorAppName)
+	})
+
+	t.Run("statefulset uses the managed options", func(subT *testing.T) {
+		limObj := newManagedRedisLimitadorObj()
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		}
+		limObj.Spec.Storage.Redis.Managed.Image = ptr.To("redis:custom")
+		limObj.Spec.Storage.Redis.Managed.Resources = &resources
+
+		statefulSet := ManagedRedisStatefulSet(limObj)
+		assert.Equal(subT, *statefulSet.Spec.Replicas, int32(1))
+		assert.Assert(subT, len(statefulSet.Spec.Template.Spec.Containers) == 1)
+		assert.Equal(subT, statefulSet.Spec.Template.Spec.Containers[0].Image, "redis:custom")
+		assert.DeepEqual(subT, statefulSet.Spec.Template.Spec.Containers[0].Resources, resources)
+		assert.Equal(subT, statefulSet.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName, ManagedRedisName(limObj))
+	})
+
+	t.Run("pvc uses the managed options", func(subT *testing.T) {
+		limObj := newManagedRedisLimitadorObj()
+		limObj.Spec.Storage.Redis.Managed.PVC = &limitadorv1alpha1.PVCGenericSpec{
+			StorageClassName: ptr.To("fast"),
+			Resources: &limitadorv1alpha1.PersistentVolumeClaimResources{
+				Requests: resource.MustParse("5Gi"),
+			},
+		}
+
+		pvc := ManagedRedisPVC(limObj)
+		assert.Equal(subT, *pvc.Spec.StorageClassName, "fast")
+		assert.Assert(subT, pvc.Spec.Resources.Requests[corev1.ResourceStorage].Equal(resource.MustParse("5Gi")))
+	})
+
+	t.Run("pvc annotated with the retain policy", func(subT *testing.T) {
+		limObj := newManagedRedisLimitadorObj()
+		assert.Equal(subT, ManagedRedisPVC(limObj).Annotations[helpers.RetainPolicyAnnotation], "Delete")
+
+		limObj.Spec.Storage.Redis.Managed.RetainPolicy = ptr.To(limitadorv1alpha1.RetainPolicyRetain)
+		assert.Equal(subT, ManagedRedisPVC(limObj).Annotations[helpers.RetainPolicyAnnotation], "Retain")
+	})
+}
@@ -0,0 +1,111 @@
+package limitador
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+const MetricsEndpoint = "/metrics"
+
+func MonitorName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-%s", limitadorObj.Name)
+}
+
+// ServiceMonitor scrapes the limitador metrics through the limitador Service.
+// It is tagged to be deleted unless the monitoring spec asks for a ServiceMonitor.
+func ServiceMonitor(limitadorObj *limitadorv1alpha1.Limitador) *monitoringv1.ServiceMonitor {
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       monitoringv1.ServiceMonitorsKind,
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MonitorName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    monitorLabels(limitadorObj),
+		},
+	}
+
+	monitorKind := limitadorObj.MonitorKind()
+	if monitorKind == nil || *monitorKind != limitadorv1alpha1.MonitorKindServiceMonitor {
+		helpers.TagObjectToDelete(serviceMonitor)
+		return serviceMonitor
+	}
+
+	spec := limitadorObj.Spec.Monitoring.ServiceMonitor
+	endpoint := monitoringv1.Endpoint{
+		Port:           "http",
+		Path:           MetricsEndpoint,
+		Scheme:         "http",
+		Interval:       ptr.Deref(spec.Interval, ""),
+		RelabelConfigs: spec.Relabelings,
+	}
+
+	serviceMonitor.Spec = monitoringv1.ServiceMonitorSpec{
+		Endpoints: []monitoringv1.Endpoint{endpoint},
+		Selector:  monitorSelector(limitadorObj),
+	}
+
+	return serviceMonitor
+}
+
+// PodMonitor scrapes the limitador metrics directly from the limitador pods.
+// It is tagged to be deleted unless the monitoring spec asks for a PodMonitor.
+func PodMonitor(limitadorObj *limitadorv1alpha1.Limitador) *monitoringv1.PodMonitor {
+	podMonitor := &monitoringv1.PodMonitor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       monitoringv1.PodMonitorsKind,
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MonitorName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    monitorLabels(limitadorObj),
+		},
+	}
+
+	monitorKind := limitadorObj.MonitorKind()
+	if monitorKind == nil || *monitorKind != limitadorv1alpha1.MonitorKindPodMonitor {
+		helpers.TagObjectToDelete(podMonitor)
+		return podMonitor
+	}
+
+	spec := limitadorObj.Spec.Monitoring.ServiceMonitor
+	podMonitor.Spec = monitoringv1.PodMonitorSpec{
+		PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+			{
+				Port:           ptr.To("http"),
+				Path:           MetricsEndpoint,
+				Scheme:         "http",
+				Interval:       ptr.Deref(spec.Interval, ""),
+				RelabelConfigs: spec.Relabelings,
+			},
+		},
+		Selector: monitorSelector(limitadorObj),
+	}
+
+	return podMonitor
+}
+
+// monitorLabels merges the monitor labels of the spec with the labels of the owned objects
+func monitorLabels(limitadorObj *limitadorv1alpha1.Limitador) map[string]string {
+	labels := map[string]string{}
+	if limitadorObj.Spec.Monitoring != nil && limitadorObj.Spec.Monitoring.ServiceMonitor != nil {
+		helpers.MergeMapStringString(&labels, limitadorObj.Spec.Monitoring.ServiceMonitor.Labels)
+	}
+	helpers.MergeMapStringString(&labels, Labels(limitadorObj))
+	return labels
+}
+
+// monitorSelector selects the limitador Service and pods, leaving out the managed Redis
+func monitorSelector(limitadorObj *limitadorv1alpha1.Limitador) metav1.LabelSelector {
+	matchLabels := SelectorLabels(limitadorObj)
+	matchLabels["app.kubernetes.io/component"] = helpers.LimitadorAppName
+	return metav1.LabelSelector{MatchLabels: matchLabels}
+}
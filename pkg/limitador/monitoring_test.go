@@ -0,0 +1,72 @@
+package limitador
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"gotest.tools/assert"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+func TestMonitors(t *testing.T) {
+	t.Run("monitoring not enabled", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(ServiceMonitor(limObj)))
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(PodMonitor(limObj)))
+	})
+
+	t.Run("service monitor", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Monitoring = &limitadorv1alpha1.Monitoring{
+			ServiceMonitor: &limitadorv1alpha1.ServiceMonitorSpec{
+				Interval: ptr.To(monitoringv1.Duration("30s")),
+				Labels:   map[string]string{"release": "prometheus", "app": "other"},
+				Relabelings: []monitoringv1.RelabelConfig{
+					{TargetLabel: "cluster", Replacement: ptr.To("east")},
+				},
+			},
+		}
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(PodMonitor(limObj)))
+
+		serviceMonitor := ServiceMonitor(limObj)
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(serviceMonitor))
+		assert.Equal(subT, serviceMonitor.Name, "limitador-some-name")
+		assert.Equal(subT, serviceMonitor.Labels["release"], "prometheus")
+		assert.Equal(subT, serviceMonitor.Labels["app"], helpers.LimitadorAppName)
+		assert.DeepEqual(subT, serviceMonitor.Spec.Selector.MatchLabels, map[string]string{
+			helpers.LabelKeyApp:               helpers.LimitadorAppName,
+			helpers.LabelKeyLimitadorResource: "some-name",
+			"app.kubernetes.io/component":     helpers.LimitadorAppName,
+		})
+		assert.Equal(subT, len(serviceMonitor.Spec.Endpoints), 1)
+		endpoint := serviceMonitor.Spec.Endpoints[0]
+		assert.Equal(subT, endpoint.Port, "http")
+		assert.Equal(subT, endpoint.Path, MetricsEndpoint)
+		assert.Equal(subT, endpoint.Scheme, "http")
+		assert.Equal(subT, endpoint.Interval, monitoringv1.Duration("30s"))
+		assert.Assert(subT, endpoint.TLSConfig == nil)
+		assert.DeepEqual(subT, endpoint.RelabelConfigs, limObj.Spec.Monitoring.ServiceMonitor.Relabelings)
+	})
+
+	t.Run("pod monitor", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Monitoring = &limitadorv1alpha1.Monitoring{
+			ServiceMonitor: &limitadorv1alpha1.ServiceMonitorSpec{
+				Kind: ptr.To(limitadorv1alpha1.MonitorKindPodMonitor),
+			},
+		}
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(ServiceMonitor(limObj)))
+
+		podMonitor := PodMonitor(limObj)
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(podMonitor))
+		assert.Equal(subT, len(podMonitor.Spec.PodMetricsEndpoints), 1)
+		endpoint := podMonitor.Spec.PodMetricsEndpoints[0]
+		assert.DeepEqual(subT, endpoint.Port, ptr.To("http"))
+		assert.Equal(subT, endpoint.Scheme, "http")
+		assert.Equal(subT, endpoint.Interval, monitoringv1.Duration(""))
+		assert.Assert(subT, endpoint.TLSConfig == nil)
+	})
+}
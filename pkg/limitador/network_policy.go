@@ -0,0 +1,283 @@
+package limitador
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/env"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+const (
+	// OperatorNamespaceEnv holds the namespace of the operator pods, allowed to reach the HTTP listener
+	OperatorNamespaceEnv = "OPERATOR_NAMESPACE"
+	OperatorAppName      = "limitador-operator"
+
+	DefaultRedisPort = 6379
+	DNSPort          = 53
+)
+
+// tracingSchemePorts are the ports of the tracing endpoints without port, by URL scheme.
+// The rpc scheme is the OTLP gRPC exporter of limitador.
+var tracingSchemePorts = map[string]int{
+	"rpc":   4317,
+	"grpc":  4317,
+	"http":  80,
+	"https": 443,
+}
+
+func NetworkPolicyName(limitadorObj *limitadorv1alpha1.Limitador) string {
+	return fmt.Sprintf("limitador-%s", limitadorObj.Name)
+}
+
+// NetworkPolicy restricts the traffic of the limitador pods. The egress rules only apply when
+// the egress mode is on. It is tagged to be deleted when the network policy is not enabled in the spec.
+func NetworkPolicy(limitadorObj *limitadorv1alpha1.Limitador, egressRules []networkingv1.NetworkPolicyEgressRule) *networkingv1.NetworkPolicy {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName(limitadorObj),
+			Namespace: limitadorObj.Namespace,
+			Labels:    Labels(limitadorObj),
+		},
+	}
+
+	spec := limitadorObj.Spec.NetworkPolicy
+	if spec == nil {
+		helpers.TagObjectToDelete(networkPolicy)
+		return networkPolicy
+	}
+
+	networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: SelectorLabels(limitadorObj)},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{tcpPort(limitadorObj.GRPCPort())},
+				From:  spec.GRPC,
+			},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{tcpPort(limitadorObj.HTTPPort())},
+				From:  httpPeers(spec),
+			},
+		},
+	}
+
+	if spec.Egress {
+		networkPolicy.Spec.PolicyTypes = append(networkPolicy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		networkPolicy.Spec.Egress = egressRules
+	}
+
+	return networkPolicy
+}
+
+// httpPeers adds the operator pods to the sources allowed to reach the HTTP listener.
+// No peer means any source.
+func httpPeers(spec *limitadorv1alpha1.NetworkPolicySpec) []networkingv1.NetworkPolicyPeer {
+	if len(spec.HTTP) == 0 {
+		return nil
+	}
+
+	peers := slices.Clone(spec.HTTP)
+	if operatorNamespace := env.GetString(OperatorNamespaceEnv, ""); operatorNamespace != "" {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: namespaceSelector(operatorNamespace),
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{helpers.LabelKeyApp: OperatorAppName},
+			},
+		})
+	}
+
+	return peers
+}
+
+// NetworkPolicyEgressRules returns the egress rules of the limitador pods: DNS resolution,
+// the Redis storage and the tracing endpoint
+func NetworkPolicyEgressRules(ctx context.Context, cl client.Reader, limitadorObj *limitadorv1alpha1.Limitador) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: ptr.To(v1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(DNSPort))},
+				{Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(DNSPort))},
+			},
+		},
+	}
+
+	storageRules, err := storageEgressRules(ctx, cl, limitadorObj)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, storageRules...)
+
+	if limitadorObj.Spec.Tracing != nil {
+		endpoint, err := url.Parse(limitadorObj.Spec.Tracing.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracing endpoint: %w", err)
+		}
+
+		port := endpoint.Port()
+		if port == "" {
+			defaultPort, ok := tracingSchemePorts[endpoint.Scheme]
+			if !ok {
+				return nil, fmt.Errorf("tracing endpoint %q without port, no default port for the scheme %q", limitadorObj.Spec.Tracing.Endpoint, endpoint.Scheme)
+			}
+			port = strconv.Itoa(defaultPort)
+		}
+		rule, err := addressEgressRule(ctx, cl, limitadorObj.Namespace, endpoint.Hostname(), port)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// storageEgressRules allows the address of the Redis storage, read from the URL of the config secret
+func storageEgressRules(ctx context.Context, cl client.Reader, limitadorObj *limitadorv1alpha1.Limitador) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	if limitadorObj.Spec.Storage == nil {
+		return nil, nil
+	}
+
+	var configSecretRef *v1.LocalObjectReference
+	switch {
+	case limitadorObj.Spec.Storage.Redis != nil:
+		configSecretRef = ManagedRedisConfigSecretRef(limitadorObj, *limitadorObj.Spec.Storage.Redis)
+	case limitadorObj.Spec.Storage.RedisCached != nil:
+		configSecretRef = limitadorObj.Spec.Storage.RedisCached.ConfigSecretRef
+	default:
+		return nil, nil
+	}
+
+	if configSecretRef == nil {
+		return nil, errors.New("there's no ConfigSecretRef set")
+	}
+	secret, err := validateRedisSecret(ctx, cl, limitadorObj.Namespace, *configSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	redisURL, err := url.Parse(string(secret.Data["URL"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	port := redisURL.Port()
+	if port == "" {
+		port = strconv.Itoa(DefaultRedisPort)
+	}
+	rule, err := addressEgressRule(ctx, cl, limitadorObj.Namespace, redisURL.Hostname(), port)
+	if err != nil {
+		return nil, err
+	}
+
+	return []networkingv1.NetworkPolicyEgressRule{rule}, nil
+}
+
+// addressEgressRule allows the TCP traffic to host:port. The port is required, as a rule without port
+// nor destination would allow any egress traffic.
+//   - IP addresses are allowed as IP blocks.
+//   - In-cluster service names, i.e. <name>, <name>.<namespace>.svc and <name>.<namespace>.svc.cluster.local,
+//     are allowed as the pods selected by the service.
+//   - Other hosts cannot be expressed in a NetworkPolicy, any destination is allowed on the port.
+func addressEgressRule(ctx context.Context, cl client.Reader, namespace, host, port string) (networkingv1.NetworkPolicyEgressRule, error) {
+	rule := networkingv1.NetworkPolicyEgressRule{}
+
+	if port == "" {
+		return rule, fmt.Errorf("no port for the host %q", host)
+	}
+	portNumber, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return rule, fmt.Errorf("invalid port %q: %w", port, err)
+	}
+	targetPort := intstr.FromInt32(int32(portNumber))
+
+	if ip := net.ParseIP(host); ip != nil {
+		prefixLength := 32
+		if ip.To4() == nil {
+			prefixLength = 128
+		}
+		rule.To = []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: fmt.Sprintf("%s/%d", ip, prefixLength)}},
+		}
+	} else if serviceKey, ok := serviceKeyFromHost(host, namespace); ok {
+		service := &v1.Service{}
+		err := cl.Get(ctx, serviceKey, service)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return rule, err
+		}
+		if err == nil && len(service.Spec.Selector) > 0 {
+			rule.To = []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: namespaceSelector(serviceKey.Namespace),
+					PodSelector:       &metav1.LabelSelector{MatchLabels: service.Spec.Selector},
+				},
+			}
+			// NetworkPolicies apply to the pod ports, after the service port translation
+			targetPort = serviceTargetPort(service, targetPort.IntVal)
+		}
+	}
+
+	rule.Ports = []networkingv1.NetworkPolicyPort{
+		{Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(targetPort)},
+	}
+
+	return rule, nil
+}
+
+// serviceKeyFromHost returns the service of in-cluster service names
+func serviceKeyFromHost(host, namespace string) (types.NamespacedName, bool) {
+	labels := strings.Split(strings.TrimSuffix(host, ".cluster.local"), ".")
+	switch {
+	case len(labels) == 1 && labels[0] != "":
+		return types.NamespacedName{Name: labels[0], Namespace: namespace}, true
+	case len(labels) == 3 && labels[2] == "svc":
+		return types.NamespacedName{Name: labels[0], Namespace: labels[1]}, true
+	default:
+		return types.NamespacedName{}, false
+	}
+}
+
+func serviceTargetPort(service *v1.Service, port int32) intstr.IntOrString {
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Port != port {
+			continue
+		}
+		// unset target port defaults to the service port
+		if servicePort.TargetPort != (intstr.IntOrString{}) {
+			return servicePort.TargetPort
+		}
+	}
+
+	return intstr.FromInt32(port)
+}
+
+func namespaceSelector(namespace string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{v1.LabelMetadataName: namespace},
+	}
+}
+
+func tcpPort(port int32) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: ptr.To(v1.ProtocolTCP),
+		Port:     ptr.To(intstr.FromInt32(port)),
+	}
+}
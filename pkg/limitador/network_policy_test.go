@@ -0,0 +1,193 @@
+package limitador
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/helpers"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	gatewayPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "gateway-system"}},
+	}
+
+	t.Run("network policy not enabled", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		assert.Assert(subT, helpers.IsObjectTaggedToDelete(NetworkPolicy(limObj, nil)))
+	})
+
+	t.Run("ingress only", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.NetworkPolicy = &limitadorv1alpha1.NetworkPolicySpec{
+			GRPC: []networkingv1.NetworkPolicyPeer{gatewayPeer},
+		}
+		networkPolicy := NetworkPolicy(limObj, []networkingv1.NetworkPolicyEgressRule{{}})
+
+		assert.Assert(subT, !helpers.IsObjectTaggedToDelete(networkPolicy))
+		assert.DeepEqual(subT, networkPolicy.Spec.PodSelector.MatchLabels, SelectorLabels(limObj))
+		assert.DeepEqual(subT, networkPolicy.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress})
+		assert.Assert(subT, networkPolicy.Spec.Egress == nil)
+		assert.DeepEqual(subT, networkPolicy.Spec.Ingress, []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{tcpPort(8001)},
+				From:  []networkingv1.NetworkPolicyPeer{gatewayPeer},
+			},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{tcpPort(8000)},
+			},
+		})
+	})
+
+	t.Run("http peers allow the operator", func(subT *testing.T) {
+		subT.Setenv(OperatorNamespaceEnv, "kuadrant-system")
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.NetworkPolicy = &limitadorv1alpha1.NetworkPolicySpec{
+			HTTP: []networkingv1.NetworkPolicyPeer{gatewayPeer},
+		}
+		networkPolicy := NetworkPolicy(limObj, nil)
+
+		assert.DeepEqual(subT, networkPolicy.Spec.Ingress[1].From, []networkingv1.NetworkPolicyPeer{
+			gatewayPeer,
+			{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kuadrant-system"}},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "limitador-operator"}},
+			},
+		})
+	})
+
+	t.Run("egress mode", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.NetworkPolicy = &limitadorv1alpha1.NetworkPolicySpec{Egress: true}
+		egressRules := []networkingv1.NetworkPolicyEgressRule{{Ports: []networkingv1.NetworkPolicyPort{tcpPort(6379)}}}
+		networkPolicy := NetworkPolicy(limObj, egressRules)
+
+		assert.DeepEqual(subT, networkPolicy.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress})
+		assert.DeepEqual(subT, networkPolicy.Spec.Egress, egressRules)
+	})
+}
+
+func TestNetworkPolicyEgressRules(t *testing.T) {
+	ctx := context.Background()
+	namespace := "some-ns"
+
+	redisSecret := func(url string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis-config", Namespace: namespace},
+			Data:       map[string][]byte{"URL": []byte(url)},
+		}
+	}
+	redisService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "redis-system"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "redis"},
+			Ports: []v1.ServicePort{
+				{Port: 6379, TargetPort: intstr.FromString("redis")},
+			},
+		},
+	}
+	redisLimitador := func() *limitadorv1alpha1.Limitador {
+		limObj := newTestLimitadorObj("some-name", namespace, nil)
+		limObj.Spec.Storage = &limitadorv1alpha1.Storage{
+			Redis: &limitadorv1alpha1.Redis{ConfigSecretRef: &v1.LocalObjectReference{Name: "redis-config"}},
+		}
+		return limObj
+	}
+	dnsRule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: ptr.To(v1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))},
+			{Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(53))},
+		},
+	}
+
+	clientFactory := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithObjects(objs...).Build()
+	}
+
+	t.Run("in memory storage only allows DNS", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", namespace, nil)
+		rules, err := NetworkPolicyEgressRules(ctx, clientFactory(), limObj)
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, rules, []networkingv1.NetworkPolicyEgressRule{dnsRule})
+	})
+
+	t.Run("redis service", func(subT *testing.T) {
+		cl := clientFactory(redisSecret("redis://redis.redis-system.svc.cluster.local:6379"), redisService)
+		rules, err := NetworkPolicyEgressRules(ctx, cl, redisLimitador())
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, rules, []networkingv1.NetworkPolicyEgressRule{
+			dnsRule,
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "redis-system"}},
+						PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "redis"}},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(intstr.FromString("redis"))},
+				},
+			},
+		})
+	})
+
+	t.Run("redis IP address with default port", func(subT *testing.T) {
+		cl := clientFactory(redisSecret("redis://:secret@10.0.0.12"))
+		rules, err := NetworkPolicyEgressRules(ctx, cl, redisLimitador())
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, rules[1], networkingv1.NetworkPolicyEgressRule{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.12/32"}}},
+			Ports: []networkingv1.NetworkPolicyPort{tcpPort(6379)},
+		})
+	})
+
+	t.Run("external redis host", func(subT *testing.T) {
+		cl := clientFactory(redisSecret("rediss://redis.example.com:6380"))
+		rules, err := NetworkPolicyEgressRules(ctx, cl, redisLimitador())
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, rules[1], networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{tcpPort(6380)},
+		})
+	})
+
+	t.Run("storage secret missing", func(subT *testing.T) {
+		_, err := NetworkPolicyEgressRules(ctx, clientFactory(), redisLimitador())
+		assert.ErrorContains(subT, err, "not found")
+	})
+
+	t.Run("tracing endpoint", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", namespace, nil)
+		limObj.Spec.Tracing = &limitadorv1alpha1.Tracing{Endpoint: "rpc://jaeger:4317"}
+		rules, err := NetworkPolicyEgressRules(ctx, clientFactory(), limObj)
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, rules[1], networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{tcpPort(4317)},
+		})
+	})
+	t.Run("tracing endpoint default port", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", namespace, nil)
+		limObj.Spec.Tracing = &limitadorv1alpha1.Tracing{Endpoint: "rpc://otel-collector.example.com"}
+		rules, err := NetworkPolicyEgressRules(ctx, clientFactory(), limObj)
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, rules[1], networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{tcpPort(4317)},
+		})
+	})
+
+	t.Run("tracing endpoint without port nor default port", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", namespace, nil)
+		limObj.Spec.Tracing = &limitadorv1alpha1.Tracing{Endpoint: "udp://otel-collector.example.com"}
+		_, err := NetworkPolicyEgressRules(ctx, clientFactory(), limObj)
+		assert.ErrorContains(subT, err, "no default port for the scheme \"udp\"")
+	})
+}
@@ -0,0 +1,93 @@
+package limitador
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+var (
+	defaultLivenessProbeTimings = limitadorv1alpha1.ProbeTimings{
+		InitialDelaySeconds: ptr.To(int32(5)),
+		TimeoutSeconds:      ptr.To(int32(2)),
+		PeriodSeconds:       ptr.To(int32(10)),
+		FailureThreshold:    ptr.To(int32(3)),
+	}
+
+	defaultReadinessProbeTimings = limitadorv1alpha1.ProbeTimings{
+		InitialDelaySeconds: ptr.To(int32(5)),
+		TimeoutSeconds:      ptr.To(int32(5)),
+		PeriodSeconds:       ptr.To(int32(10)),
+		FailureThreshold:    ptr.To(int32(3)),
+	}
+
+	// the startup probe allows 5 minutes of warm-up by default
+	defaultStartupProbeTimings = limitadorv1alpha1.ProbeTimings{
+		InitialDelaySeconds: ptr.To(int32(0)),
+		TimeoutSeconds:      ptr.To(int32(2)),
+		PeriodSeconds:       ptr.To(int32(5)),
+		FailureThreshold:    ptr.To(int32(60)),
+	}
+)
+
+func LivenessProbe(limObj *limitadorv1alpha1.Limitador) *v1.Probe {
+	var timings *limitadorv1alpha1.ProbeTimings
+	if limObj.Spec.Probes != nil {
+		timings = limObj.Spec.Probes.Liveness
+	}
+
+	return probe(limObj, defaultLivenessProbeTimings, timings)
+}
+
+func ReadinessProbe(limObj *limitadorv1alpha1.Limitador) *v1.Probe {
+	var timings *limitadorv1alpha1.ProbeTimings
+	if limObj.Spec.Probes != nil {
+		timings = limObj.Spec.Probes.Readiness
+	}
+
+	return probe(limObj, defaultReadinessProbeTimings, timings)
+}
+
+// StartupProbe returns nil unless the startup probe is enabled in the spec
+func StartupProbe(limObj *limitadorv1alpha1.Limitador) *v1.Probe {
+	if limObj.Spec.Probes == nil || limObj.Spec.Probes.Startup == nil {
+		return nil
+	}
+
+	return probe(limObj, defaultStartupProbeTimings, limObj.Spec.Probes.Startup)
+}
+
+func probe(limObj *limitadorv1alpha1.Limitador, defaults limitadorv1alpha1.ProbeTimings, timings *limitadorv1alpha1.ProbeTimings) *v1.Probe {
+	if timings == nil {
+		timings = &limitadorv1alpha1.ProbeTimings{}
+	}
+
+	return &v1.Probe{
+		ProbeHandler:        probeHandler(limObj),
+		InitialDelaySeconds: ptr.Deref(timings.InitialDelaySeconds, *defaults.InitialDelaySeconds),
+		TimeoutSeconds:      ptr.Deref(timings.TimeoutSeconds, *defaults.TimeoutSeconds),
+		PeriodSeconds:       ptr.Deref(timings.PeriodSeconds, *defaults.PeriodSeconds),
+		SuccessThreshold:    1,
+		FailureThreshold:    ptr.Deref(timings.FailureThreshold, *defaults.FailureThreshold),
+	}
+}
+
+func probeHandler(limObj *limitadorv1alpha1.Limitador) v1.ProbeHandler {
+	if limObj.ProbeType() == limitadorv1alpha1.ProbeTypeGRPC {
+		return v1.ProbeHandler{
+			GRPC: &v1.GRPCAction{
+				Port: limObj.GRPCPort(),
+			},
+		}
+	}
+
+	return v1.ProbeHandler{
+		HTTPGet: &v1.HTTPGetAction{
+			Path:   StatusEndpoint,
+			Port:   intstr.FromInt(int(limObj.HTTPPort())),
+			Scheme: v1.URISchemeHTTP,
+		},
+	}
+}
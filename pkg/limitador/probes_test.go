@@ -0,0 +1,82 @@
+package limitador
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+func TestProbes(t *testing.T) {
+	t.Run("default probes", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		container := Deployment(limObj, DeploymentOptions{}).Spec.Template.Spec.Containers[0]
+
+		assert.DeepEqual(subT, container.LivenessProbe, &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   StatusEndpoint,
+					Port:   intstr.FromInt(int(limObj.HTTPPort())),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+			InitialDelaySeconds: 5,
+			TimeoutSeconds:      2,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		})
+		assert.Equal(subT, container.ReadinessProbe.TimeoutSeconds, int32(5))
+		assert.Assert(subT, container.StartupProbe == nil)
+	})
+
+	t.Run("custom timings", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Probes = &limitadorv1alpha1.Probes{
+			Liveness:  &limitadorv1alpha1.ProbeTimings{InitialDelaySeconds: ptr.To(int32(30))},
+			Readiness: &limitadorv1alpha1.ProbeTimings{PeriodSeconds: ptr.To(int32(3)), FailureThreshold: ptr.To(int32(5))},
+		}
+		container := Deployment(limObj, DeploymentOptions{}).Spec.Template.Spec.Containers[0]
+
+		assert.Equal(subT, container.LivenessProbe.InitialDelaySeconds, int32(30))
+		assert.Equal(subT, container.LivenessProbe.TimeoutSeconds, int32(2))
+		assert.Equal(subT, container.ReadinessProbe.InitialDelaySeconds, int32(5))
+		assert.Equal(subT, container.ReadinessProbe.PeriodSeconds, int32(3))
+		assert.Equal(subT, container.ReadinessProbe.FailureThreshold, int32(5))
+	})
+
+	t.Run("startup probe", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Probes = &limitadorv1alpha1.Probes{
+			Startup: &limitadorv1alpha1.ProbeTimings{FailureThreshold: ptr.To(int32(120))},
+		}
+		startupProbe := Deployment(limObj, DeploymentOptions{}).Spec.Template.Spec.Containers[0].StartupProbe
+
+		assert.Assert(subT, startupProbe != nil)
+		assert.Equal(subT, startupProbe.HTTPGet.Path, StatusEndpoint)
+		assert.Equal(subT, startupProbe.PeriodSeconds, int32(5))
+		assert.Equal(subT, startupProbe.FailureThreshold, int32(120))
+		assert.Equal(subT, startupProbe.SuccessThreshold, int32(1))
+	})
+
+	t.Run("grpc probes on the rls port", func(subT *testing.T) {
+		limObj := newTestLimitadorObj("some-name", "some-ns", nil)
+		limObj.Spec.Listener = &limitadorv1alpha1.Listener{
+			GRPC: &limitadorv1alpha1.TransportProtocol{Port: ptr.To(int32(9000))},
+		}
+		limObj.Spec.Probes = &limitadorv1alpha1.Probes{
+			Type:    ptr.To(limitadorv1alpha1.ProbeTypeGRPC),
+			Startup: &limitadorv1alpha1.ProbeTimings{},
+		}
+		container := Deployment(limObj, DeploymentOptions{}).Spec.Template.Spec.Containers[0]
+
+		for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+			assert.Assert(subT, probe.HTTPGet == nil)
+			assert.DeepEqual(subT, probe.GRPC, &corev1.GRPCAction{Port: 9000})
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package limitador
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// PVCDrift compares the desired persistent volume claim with the existing one. It returns whether
+// the storage request of the existing claim has to be increased, and the changes that cannot be
+// applied to the existing claim as its fields are immutable.
+func PVCDrift(desired, existing *v1.PersistentVolumeClaim) (bool, []string) {
+	var drift []string
+
+	if desired.Spec.StorageClassName != nil && *desired.Spec.StorageClassName != ptr.Deref(existing.Spec.StorageClassName, "") {
+		drift = append(drift, fmt.Sprintf("storageClassName cannot change from %q to %q",
+			ptr.Deref(existing.Spec.StorageClassName, ""), *desired.Spec.StorageClassName))
+	}
+
+	if desired.Spec.VolumeName != "" && desired.Spec.VolumeName != existing.Spec.VolumeName {
+		drift = append(drift, fmt.Sprintf("volumeName cannot change from %q to %q",
+			existing.Spec.VolumeName, desired.Spec.VolumeName))
+	}
+
+	desiredStorage := desired.Spec.Resources.Requests[v1.ResourceStorage]
+	existingStorage := existing.Spec.Resources.Requests[v1.ResourceStorage]
+	switch desiredStorage.Cmp(existingStorage) {
+	case 1:
+		return true, drift
+	case -1:
+		drift = append(drift, fmt.Sprintf("storage request cannot shrink from %s to %s",
+			existingStorage.String(), desiredStorage.String()))
+	}
+
+	return false, drift
+}
@@ -0,0 +1,57 @@
+package limitador
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestPVCDrift(t *testing.T) {
+	pvc := func(storage string) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To("standard"),
+				VolumeName:       "pv-1",
+				Resources: v1.VolumeResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(storage)},
+				},
+			},
+		}
+	}
+
+	t.Run("unchanged", func(subT *testing.T) {
+		desired := pvc("1Gi")
+		desired.Spec.StorageClassName = nil
+		desired.Spec.VolumeName = ""
+		expand, drift := PVCDrift(desired, pvc("1024Mi"))
+		assert.Assert(subT, !expand)
+		assert.Assert(subT, drift == nil)
+	})
+
+	t.Run("storage increase", func(subT *testing.T) {
+		expand, drift := PVCDrift(pvc("2Gi"), pvc("1Gi"))
+		assert.Assert(subT, expand)
+		assert.Assert(subT, drift == nil)
+	})
+
+	t.Run("storage shrink", func(subT *testing.T) {
+		expand, drift := PVCDrift(pvc("1Gi"), pvc("2Gi"))
+		assert.Assert(subT, !expand)
+		assert.DeepEqual(subT, drift, []string{"storage request cannot shrink from 2Gi to 1Gi"})
+	})
+
+	t.Run("immutable fields", func(subT *testing.T) {
+		desired := pvc("1Gi")
+		desired.Spec.StorageClassName = ptr.To("fast")
+		desired.Spec.VolumeName = "pv-2"
+		expand, drift := PVCDrift(desired, pvc("1Gi"))
+		assert.Assert(subT, !expand)
+		assert.DeepEqual(subT, drift, []string{
+			`storageClassName cannot change from "standard" to "fast"`,
+			`volumeName cannot change from "pv-1" to "pv-2"`,
+		})
+	})
+}
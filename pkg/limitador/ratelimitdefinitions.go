@@ -0,0 +1,137 @@
+package limitador
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+// DefinitionsLimits is the outcome of aggregating the rate limit definitions of a Limitador object
+type DefinitionsLimits struct {
+	// Limits accepted, in aggregation order
+	Limits []limitadorv1alpha1.RateLimit
+	// Statuses of the limits of each definition, in the same order as the definition spec
+	Statuses map[types.NamespacedName][]limitadorv1alpha1.RateLimitStatus
+	// NotAllowed holds the definitions whose namespace is not allowed by the Limitador object
+	NotAllowed map[types.NamespacedName]bool
+}
+
+// AggregateRateLimitDefinitions selects the definitions referencing the Limitador object and returns
+// the limits to be added to its limits ConfigMap.
+// Definitions are processed from the oldest to the newest. An invalid limit is not accepted, neither is
+// a named limit whose name is already taken by an inline limit, a limit of the spec.limitsFrom sources
+// or a limit previously accepted.
+// The definitions of another namespace than the Limitador object are only accepted when their namespace
+// is allowed by spec.allowedRateLimitDefinitionNamespaces, and their limits only for the limit namespaces
+// allowed there.
+func AggregateRateLimitDefinitions(limObj *limitadorv1alpha1.Limitador, sourcesLimits []limitadorv1alpha1.RateLimit, definitions []limitadorv1alpha1.RateLimitDefinition) DefinitionsLimits {
+	result := DefinitionsLimits{
+		Limits:     []limitadorv1alpha1.RateLimit{},
+		Statuses:   map[types.NamespacedName][]limitadorv1alpha1.RateLimitStatus{},
+		NotAllowed: map[types.NamespacedName]bool{},
+	}
+
+	selected := make([]limitadorv1alpha1.RateLimitDefinition, 0, len(definitions))
+	for idx := range definitions {
+		if definitions[idx].LimitadorKey() == (types.NamespacedName{Name: limObj.Name, Namespace: limObj.Namespace}) {
+			selected = append(selected, definitions[idx])
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		if !selected[i].CreationTimestamp.Equal(&selected[j].CreationTimestamp) {
+			return selected[i].CreationTimestamp.Before(&selected[j].CreationTimestamp)
+		}
+		if selected[i].Namespace != selected[j].Namespace {
+			return selected[i].Namespace < selected[j].Namespace
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	names := map[string]string{}
+	for _, limit := range append(append([]limitadorv1alpha1.RateLimit{}, limObj.Limits()...), sourcesLimits...) {
+		if limit.Name != "" {
+			names[limit.Name] = fmt.Sprintf("Limitador %s", limObj.Name)
+		}
+	}
+
+	for idx := range selected {
+		definition := &selected[idx]
+		definitionKey := types.NamespacedName{Name: definition.Name, Namespace: definition.Namespace}
+		statuses := make([]limitadorv1alpha1.RateLimitStatus, 0, len(definition.Limits()))
+
+		allowedLimitNamespaces, allowed := rateLimitDefinitionAllowedLimitNamespaces(limObj, definition.Namespace)
+		if !allowed {
+			for _, limit := range definition.Limits() {
+				statuses = append(statuses, limitadorv1alpha1.RateLimitStatus{
+					Name:     limit.Name,
+					Accepted: false,
+					Message:  fmt.Sprintf("namespace %s not allowed by Limitador %s", definition.Namespace, limObj.Name),
+				})
+			}
+			result.Statuses[definitionKey] = statuses
+			result.NotAllowed[definitionKey] = true
+			continue
+		}
+
+		limitsPath := field.NewPath("spec", "limits")
+		errs := limitadorv1alpha1.ValidateRateLimits(definition.Limits(), limitsPath)
+
+		for limitIdx, limit := range definition.Limits() {
+			status := limitadorv1alpha1.RateLimitStatus{Name: limit.Name, Accepted: true}
+
+			if limitErrs := limitErrors(errs, limitsPath.Index(limitIdx)); len(limitErrs) > 0 {
+				status.Accepted = false
+				status.Message = limitErrs.ToAggregate().Error()
+			} else if allowedLimitNamespaces != nil && !slices.Contains(allowedLimitNamespaces, limit.Namespace) {
+				status.Accepted = false
+				status.Message = fmt.Sprintf("limit namespace %s not allowed by Limitador %s", limit.Namespace, limObj.Name)
+			} else if owner, ok := names[limit.Name]; limit.Name != "" && ok {
+				status.Accepted = false
+				status.Message = fmt.Sprintf("limit name %s already defined by %s", limit.Name, owner)
+			} else {
+				if limit.Name != "" {
+					names[limit.Name] = fmt.Sprintf("RateLimitDefinition %s", definitionKey)
+				}
+				result.Limits = append(result.Limits, limit)
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		result.Statuses[definitionKey] = statuses
+	}
+
+	return result
+}
+
+// rateLimitDefinitionAllowedLimitNamespaces tells whether the definitions of the namespace may add limits
+// to the Limitador object, and the limit namespaces they are restricted to. The definitions of the
+// namespace of the Limitador object are not restricted.
+func rateLimitDefinitionAllowedLimitNamespaces(limObj *limitadorv1alpha1.Limitador, namespace string) ([]string, bool) {
+	if namespace == limObj.Namespace {
+		return nil, true
+	}
+
+	for _, allowed := range limObj.Spec.AllowedRateLimitDefinitionNamespaces {
+		if allowed.Namespace == namespace {
+			return allowed.LimitNamespaces, true
+		}
+	}
+
+	return nil, false
+}
+
+// limitErrors returns the errors of the limit at the given path
+func limitErrors(errs field.ErrorList, limitPath *field.Path) field.ErrorList {
+	return errs.Filter(func(err error) bool {
+		fieldErr, ok := err.(*field.Error)
+		return !ok || !strings.HasPrefix(fieldErr.Field, limitPath.String()+".")
+	})
+}
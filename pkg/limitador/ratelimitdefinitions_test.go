@@ -0,0 +1,180 @@
+package limitador
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+func newTestRateLimitDefinition(name, namespace string, created time.Time, ref limitadorv1alpha1.LimitadorReference, limits ...limitadorv1alpha1.RateLimit) limitadorv1alpha1.RateLimitDefinition {
+	return limitadorv1alpha1.RateLimitDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: limitadorv1alpha1.RateLimitDefinitionSpec{
+			LimitadorRef: ref,
+			Limits:       limits,
+		},
+	}
+}
+
+// newTestRateLimitDefinitionsLimitadorObj allows the definitions of the team-a and team-b namespaces
+func newTestRateLimitDefinitionsLimitadorObj(limits []limitadorv1alpha1.RateLimit) *limitadorv1alpha1.Limitador {
+	limObj := newTestLimitadorObj("some-name", "some-ns", limits)
+	limObj.Spec.AllowedRateLimitDefinitionNamespaces = []limitadorv1alpha1.AllowedRateLimitDefinitionNamespace{
+		{Namespace: "team-a", LimitNamespaces: []string{"a", "new"}},
+		{Namespace: "team-b", LimitNamespaces: []string{"b", "old"}},
+	}
+	return limObj
+}
+
+func TestAggregateRateLimitDefinitions(t *testing.T) {
+	now := time.Now()
+	ref := limitadorv1alpha1.LimitadorReference{Name: "some-name", Namespace: "some-ns"}
+
+	t.Run("definitions referencing other limitadors are ignored", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj(nil)
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("a", "team-a", now, limitadorv1alpha1.LimitadorReference{Name: "other", Namespace: "some-ns"},
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1}),
+			// namespace defaults to the one of the definition
+			newTestRateLimitDefinition("b", "team-b", now, limitadorv1alpha1.LimitadorReference{Name: "some-name"},
+				limitadorv1alpha1.RateLimit{Namespace: "b", MaxValue: 1, Seconds: 1}),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, nil, definitions)
+		assert.Assert(subT, len(result.Limits) == 0)
+		assert.Assert(subT, len(result.Statuses) == 0)
+	})
+
+	t.Run("limits are aggregated from the oldest definition", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj(nil)
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("new", "team-a", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "new", MaxValue: 1, Seconds: 1}),
+			newTestRateLimitDefinition("old", "team-b", now.Add(-time.Hour), ref,
+				limitadorv1alpha1.RateLimit{Namespace: "old", MaxValue: 1, Seconds: 1}),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, nil, definitions)
+		assert.Assert(subT, len(result.Limits) == 2)
+		assert.Equal(subT, result.Limits[0].Namespace, "old")
+		assert.Equal(subT, result.Limits[1].Namespace, "new")
+		assert.DeepEqual(subT, result.Statuses[types.NamespacedName{Name: "new", Namespace: "team-a"}],
+			[]limitadorv1alpha1.RateLimitStatus{{Accepted: true}})
+	})
+
+	t.Run("conflicting limit names are not accepted", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj([]limitadorv1alpha1.RateLimit{
+			{Namespace: "inline", MaxValue: 1, Seconds: 1, Name: "inline"},
+		})
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("old", "team-a", now.Add(-time.Hour), ref,
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1, Name: "shared"},
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1, Name: "inline"},
+			),
+			newTestRateLimitDefinition("new", "team-b", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "b", MaxValue: 1, Seconds: 1, Name: "shared"},
+				limitadorv1alpha1.RateLimit{Namespace: "b", MaxValue: 1, Seconds: 1, Name: "own"},
+			),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, nil, definitions)
+		assert.Assert(subT, len(result.Limits) == 2)
+		assert.Equal(subT, result.Limits[0].Name, "shared")
+		assert.Equal(subT, result.Limits[1].Name, "own")
+
+		assert.DeepEqual(subT, result.Statuses[types.NamespacedName{Name: "old", Namespace: "team-a"}],
+			[]limitadorv1alpha1.RateLimitStatus{
+				{Name: "shared", Accepted: true},
+				{Name: "inline", Accepted: false, Message: "limit name inline already defined by Limitador some-name"},
+			})
+		assert.DeepEqual(subT, result.Statuses[types.NamespacedName{Name: "new", Namespace: "team-b"}],
+			[]limitadorv1alpha1.RateLimitStatus{
+				{Name: "shared", Accepted: false, Message: "limit name shared already defined by RateLimitDefinition team-a/old"},
+				{Name: "own", Accepted: true},
+			})
+	})
+
+	t.Run("limit names of the limits sources are taken", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj(nil)
+		sourcesLimits := []limitadorv1alpha1.RateLimit{{Namespace: "sourced", MaxValue: 1, Seconds: 1, Name: "sourced"}}
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("a", "team-a", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1, Name: "sourced"}),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, sourcesLimits, definitions)
+		assert.Assert(subT, len(result.Limits) == 0)
+		assert.DeepEqual(subT, result.Statuses[types.NamespacedName{Name: "a", Namespace: "team-a"}],
+			[]limitadorv1alpha1.RateLimitStatus{
+				{Name: "sourced", Accepted: false, Message: "limit name sourced already defined by Limitador some-name"},
+			})
+	})
+
+	t.Run("invalid limits are not accepted", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj(nil)
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("a", "team-a", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1, Name: "valid"},
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1, Name: "invalid", Conditions: []string{"a == 'b"}},
+			),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, nil, definitions)
+		assert.Assert(subT, len(result.Limits) == 1)
+		assert.Equal(subT, result.Limits[0].Name, "valid")
+
+		statuses := result.Statuses[types.NamespacedName{Name: "a", Namespace: "team-a"}]
+		assert.Assert(subT, statuses[0].Accepted)
+		assert.Assert(subT, !statuses[1].Accepted)
+		assert.Assert(subT, strings.HasPrefix(statuses[1].Message, "spec.limits[1].conditions[0]: Invalid value"))
+	})
+
+	t.Run("definitions of namespaces not allowed are not accepted", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj(nil)
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("c", "team-c", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "c", MaxValue: 1, Seconds: 1, Name: "c"}),
+			// the definitions of the namespace of the limitador object are always allowed
+			newTestRateLimitDefinition("local", "some-ns", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "any", MaxValue: 1, Seconds: 1}),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, nil, definitions)
+		assert.Assert(subT, len(result.Limits) == 1)
+		assert.Equal(subT, result.Limits[0].Namespace, "any")
+		assert.DeepEqual(subT, result.NotAllowed, map[types.NamespacedName]bool{{Name: "c", Namespace: "team-c"}: true})
+		assert.DeepEqual(subT, result.Statuses[types.NamespacedName{Name: "c", Namespace: "team-c"}],
+			[]limitadorv1alpha1.RateLimitStatus{
+				{Name: "c", Accepted: false, Message: "namespace team-c not allowed by Limitador some-name"},
+			})
+	})
+
+	t.Run("limit namespaces not allowed are not accepted", func(subT *testing.T) {
+		limObj := newTestRateLimitDefinitionsLimitadorObj(nil)
+		definitions := []limitadorv1alpha1.RateLimitDefinition{
+			newTestRateLimitDefinition("a", "team-a", now, ref,
+				limitadorv1alpha1.RateLimit{Namespace: "a", MaxValue: 1, Seconds: 1},
+				limitadorv1alpha1.RateLimit{Namespace: "b", MaxValue: 1, Seconds: 1},
+			),
+		}
+
+		result := AggregateRateLimitDefinitions(limObj, nil, definitions)
+		assert.Assert(subT, len(result.Limits) == 1)
+		assert.Equal(subT, result.Limits[0].Namespace, "a")
+		assert.DeepEqual(subT, result.Statuses[types.NamespacedName{Name: "a", Namespace: "team-a"}],
+			[]limitadorv1alpha1.RateLimitStatus{
+				{Accepted: true},
+				{Accepted: false, Message: "limit namespace b not allowed by Limitador some-name"},
+			})
+	})
+}
@@ -10,12 +10,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func RedisCachedDeploymentOptions(ctx context.Context, cl client.Client, defSecretNamespace string, redisCachedObj limitadorv1alpha1.RedisCached) (DeploymentStorageOptions, error) {
+func RedisCachedDeploymentOptions(ctx context.Context, cl client.Reader, defSecretNamespace string, redisCachedObj limitadorv1alpha1.RedisCached) (DeploymentStorageOptions, error) {
 	if redisCachedObj.ConfigSecretRef == nil {
 		return DeploymentStorageOptions{}, errors.New("there's no ConfigSecretRef set")
 	}
 
-	err := validateRedisSecret(ctx, cl, defSecretNamespace, *redisCachedObj.ConfigSecretRef)
+	secret, err := validateRedisSecret(ctx, cl, defSecretNamespace, *redisCachedObj.ConfigSecretRef)
+	if err != nil {
+		return DeploymentStorageOptions{}, err
+	}
+
+	tlsOptions, tlsData, err := redisTLSOptions(ctx, cl, defSecretNamespace, redisCachedObj.TLS)
 	if err != nil {
 		return DeploymentStorageOptions{}, err
 	}
@@ -37,7 +42,11 @@ func RedisCachedDeploymentOptions(ctx context.Context, cl client.Client, defSecr
 	}
 
 	return DeploymentStorageOptions{
-		Args: command,
+		Args:           command,
+		EnvVar:         tlsOptions.EnvVar,
+		VolumeMounts:   tlsOptions.VolumeMounts,
+		Volumes:        tlsOptions.Volumes,
+		PodAnnotations: redisPodAnnotations(secret, tlsData),
 		DeploymentStrategy: appsv1.DeploymentStrategy{
 			Type:          appsv1.RollingUpdateDeploymentStrategyType,
 			RollingUpdate: &appsv1.RollingUpdateDeployment{},
@@ -92,6 +92,9 @@ func TestRedisCachedDeploymentOptions(t *testing.T) {
 					Type:          appsv1.RollingUpdateDeploymentStrategyType,
 					RollingUpdate: &appsv1.RollingUpdateDeployment{},
 				},
+				PodAnnotations: map[string]string{
+					limitadorv1alpha1.PodAnnotationStorageConfigHash: secretDataHash(redisSecret.Data),
+				},
 			},
 		)
 	})
@@ -131,6 +134,9 @@ func TestRedisCachedDeploymentOptions(t *testing.T) {
 					Type:          appsv1.RollingUpdateDeploymentStrategyType,
 					RollingUpdate: &appsv1.RollingUpdateDeployment{},
 				},
+				PodAnnotations: map[string]string{
+					limitadorv1alpha1.PodAnnotationStorageConfigHash: secretDataHash(redisSecret.Data),
+				},
 			},
 		)
 	})
@@ -2,7 +2,10 @@ package limitador
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"sort"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -12,18 +15,29 @@ import (
 	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
 )
 
-func RedisDeploymentOptions(ctx context.Context, cl client.Client, defSecretNamespace string, redisObj limitadorv1alpha1.Redis) (DeploymentStorageOptions, error) {
+func RedisDeploymentOptions(ctx context.Context, cl client.Reader, defSecretNamespace string, redisObj limitadorv1alpha1.Redis) (DeploymentStorageOptions, error) {
 	if redisObj.ConfigSecretRef == nil {
 		return DeploymentStorageOptions{}, errors.New("there's no ConfigSecretRef set")
 	}
 
-	err := validateRedisSecret(ctx, cl, defSecretNamespace, *redisObj.ConfigSecretRef)
+	secret, err := validateRedisSecret(ctx, cl, defSecretNamespace, *redisObj.ConfigSecretRef)
 	if err != nil {
 		return DeploymentStorageOptions{}, err
 	}
 
+	tlsOptions, tlsData, err := redisTLSOptions(ctx, cl, defSecretNamespace, redisObj.TLS)
+	if err != nil {
+		return DeploymentStorageOptions{}, err
+	}
+
+	args := []string{"redis", "$(LIMITADOR_OPERATOR_REDIS_URL)"}
+
 	return DeploymentStorageOptions{
-		Args: []string{"redis", "$(LIMITADOR_OPERATOR_REDIS_URL)"},
+		Args:           args,
+		EnvVar:         tlsOptions.EnvVar,
+		VolumeMounts:   tlsOptions.VolumeMounts,
+		Volumes:        tlsOptions.Volumes,
+		PodAnnotations: redisPodAnnotations(secret, tlsData),
 		DeploymentStrategy: appsv1.DeploymentStrategy{
 			Type:          appsv1.RollingUpdateDeploymentStrategyType,
 			RollingUpdate: &appsv1.RollingUpdateDeployment{},
@@ -52,7 +66,7 @@ func DeploymentEnvVar(configSecretRef *v1.LocalObjectReference) ([]v1.EnvVar, er
 	return env, nil
 }
 
-func validateRedisSecret(ctx context.Context, cl client.Client, defSecretNamespace string, secretRef v1.LocalObjectReference) error {
+func validateRedisSecret(ctx context.Context, cl client.Reader, defSecretNamespace string, secretRef v1.LocalObjectReference) (*v1.Secret, error) {
 	secret := &v1.Secret{}
 	if err := cl.Get(
 		ctx,
@@ -63,13 +77,49 @@ func validateRedisSecret(ctx context.Context, cl client.Client, defSecretNamespa
 		secret,
 	); err != nil {
 		// Must exist, so if it does not, also return err
-		return err
+		return nil, err
 	}
 
 	// nil map behaves as empty map when reading
 	if _, ok := secret.Data["URL"]; ok {
-		return nil
+		return secret, nil
+	}
+
+	return nil, errors.New("the storage config Secret doesn't have the `URL` field")
+}
+
+// redisPodAnnotations returns the pod template annotations tracking the content of the
+// storage config secret and of the mounted TLS config. The URL is read from an env var,
+// which is only resolved on pod start, so any change to the secret needs to roll the pods out.
+func redisPodAnnotations(secret *v1.Secret, tlsData map[string][]byte) map[string]string {
+	// Secret keys cannot contain "/", so they never collide with the TLS data keys
+	data := make(map[string][]byte, len(secret.Data)+len(tlsData))
+	for key, value := range secret.Data {
+		data[key] = value
+	}
+	for key, value := range tlsData {
+		data[key] = value
+	}
+
+	return map[string]string{
+		limitadorv1alpha1.PodAnnotationStorageConfigHash: secretDataHash(data),
+	}
+}
+
+func secretDataHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
 	}
 
-	return errors.New("the storage config Secret doesn't have the `URL` field")
+	return hex.EncodeToString(hash.Sum(nil))
 }
@@ -101,9 +101,75 @@ func TestRedisDeploymentOptions(t *testing.T) {
 					Type:          appsv1.RollingUpdateDeploymentStrategyType,
 					RollingUpdate: &appsv1.RollingUpdateDeployment{},
 				},
+				PodAnnotations: map[string]string{
+					limitadorv1alpha1.PodAnnotationStorageConfigHash: secretDataHash(redisSecret.Data),
+				},
 			},
 		)
 	})
+
+	t.Run("redis options with tls", func(subT *testing.T) {
+		redisSecret := &v1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: "redisSecret", Namespace: namespace},
+			StringData: map[string]string{"URL": "rediss://example.com:6379"},
+			Type:       v1.SecretTypeOpaque,
+		}
+		redisSecret.Data = helperGetSecretDataFromStringData(redisSecret.StringData)
+		caSecret := &v1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: "redisCA", Namespace: namespace},
+			Data:       helperGetSecretDataFromStringData(map[string]string{"ca.crt": "ca"}),
+			Type:       v1.SecretTypeOpaque,
+		}
+
+		cl := clientFactory(subT, []client.Object{redisSecret, caSecret})
+		redisObj := limitadorv1alpha1.Redis{
+			ConfigSecretRef: &v1.LocalObjectReference{Name: "redisSecret"},
+			TLS: &limitadorv1alpha1.RedisTLS{
+				CABundle: &limitadorv1alpha1.CABundleSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "redisCA"},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+		}
+		options, err := RedisDeploymentOptions(ctx, cl, namespace, redisObj)
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, options.Args, []string{"redis", "$(LIMITADOR_OPERATOR_REDIS_URL)"})
+		assert.DeepEqual(subT, options.EnvVar, []v1.EnvVar{
+			{Name: "SSL_CERT_FILE", Value: "/home/limitador/redis-tls/redis-ca-bundle/ca.crt"},
+		})
+		assert.Assert(subT, len(options.VolumeMounts) == 1)
+		assert.Assert(subT, len(options.Volumes) == 1)
+		assert.Assert(subT,
+			options.PodAnnotations[limitadorv1alpha1.PodAnnotationStorageConfigHash] != secretDataHash(redisSecret.Data))
+	})
+}
+
+func TestRedisPodAnnotations(t *testing.T) {
+	t.Run("hash is stable", func(subT *testing.T) {
+		secret := &v1.Secret{
+			Data: helperGetSecretDataFromStringData(map[string]string{"URL": "redis://example.com:6379", "other": "a"}),
+		}
+		otherSecret := &v1.Secret{
+			Data: helperGetSecretDataFromStringData(map[string]string{"other": "a", "URL": "redis://example.com:6379"}),
+		}
+		assert.DeepEqual(subT, redisPodAnnotations(secret, nil), redisPodAnnotations(otherSecret, nil))
+	})
+
+	t.Run("hash changes with the URL", func(subT *testing.T) {
+		secret := &v1.Secret{
+			Data: helperGetSecretDataFromStringData(map[string]string{"URL": "redis://example.com:6379"}),
+		}
+		rotatedSecret := &v1.Secret{
+			Data: helperGetSecretDataFromStringData(map[string]string{"URL": "redis://other.example.com:6379"}),
+		}
+		assert.Assert(subT,
+			redisPodAnnotations(secret, nil)[limitadorv1alpha1.PodAnnotationStorageConfigHash] !=
+				redisPodAnnotations(rotatedSecret, nil)[limitadorv1alpha1.PodAnnotationStorageConfigHash])
+	})
 }
 
 func TestDeploymentEnvVar(t *testing.T) {
@@ -0,0 +1,148 @@
+package limitador
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+const (
+	RedisTLSMountPath       = "/home/limitador/redis-tls"
+	RedisCABundleVolumeName = "redis-ca-bundle"
+	RedisCABundleFileName   = "ca.crt"
+	// The CA certificates trusted by the TLS client of limitador default to the file
+	// pointed by this env var, instead of the system trust store.
+	// There is no such env var, nor limitador option, for a client certificate.
+	RedisCABundleEnvVarName = "SSL_CERT_FILE"
+)
+
+// redisTLSOptions validates the Redis TLS config and returns the storage options to mount it,
+// along with the mounted content, to be tracked by the storage config hash.
+func redisTLSOptions(ctx context.Context, cl client.Reader, defNamespace string, tlsObj *limitadorv1alpha1.RedisTLS) (DeploymentStorageOptions, map[string][]byte, error) {
+	options := DeploymentStorageOptions{}
+	data := map[string][]byte{}
+
+	if tlsObj == nil || tlsObj.CABundle == nil {
+		return options, data, nil
+	}
+
+	caBundle, err := getRedisCABundle(ctx, cl, defNamespace, *tlsObj.CABundle)
+	if err != nil {
+		return options, nil, err
+	}
+	data[filepath.Join(RedisCABundleVolumeName, RedisCABundleFileName)] = caBundle
+
+	options.EnvVar = append(options.EnvVar, v1.EnvVar{
+		Name:  RedisCABundleEnvVarName,
+		Value: filepath.Join(RedisTLSMountPath, RedisCABundleVolumeName, RedisCABundleFileName),
+	})
+	options.VolumeMounts = append(options.VolumeMounts, v1.VolumeMount{
+		ReadOnly:  true,
+		Name:      RedisCABundleVolumeName,
+		MountPath: filepath.Join(RedisTLSMountPath, RedisCABundleVolumeName),
+	})
+	options.Volumes = append(options.Volumes, redisCABundleVolume(*tlsObj.CABundle))
+
+	return options, data, nil
+}
+
+// RedisCABundleConfigMapNames returns the names of the ConfigMaps holding the Redis CA bundle
+func RedisCABundleConfigMapNames(limObj *limitadorv1alpha1.Limitador) []string {
+	var tlsObj *limitadorv1alpha1.RedisTLS
+	if limObj.Spec.Storage != nil {
+		if limObj.Spec.Storage.Redis != nil {
+			tlsObj = limObj.Spec.Storage.Redis.TLS
+		} else if limObj.Spec.Storage.RedisCached != nil {
+			tlsObj = limObj.Spec.Storage.RedisCached.TLS
+		}
+	}
+
+	if tlsObj == nil || tlsObj.CABundle == nil || tlsObj.CABundle.ConfigMapKeyRef == nil {
+		return nil
+	}
+
+	return []string{tlsObj.CABundle.ConfigMapKeyRef.Name}
+}
+
+func redisCABundleVolume(caBundle limitadorv1alpha1.CABundleSource) v1.Volume {
+	volume := v1.Volume{Name: RedisCABundleVolumeName}
+
+	if caBundle.ConfigMapKeyRef != nil {
+		volume.VolumeSource = v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: caBundle.ConfigMapKeyRef.LocalObjectReference,
+				Items: []v1.KeyToPath{
+					{Key: caBundle.ConfigMapKeyRef.Key, Path: RedisCABundleFileName},
+				},
+			},
+		}
+		return volume
+	}
+
+	volume.VolumeSource = v1.VolumeSource{
+		Secret: &v1.SecretVolumeSource{
+			SecretName: caBundle.SecretKeyRef.Name,
+			Items: []v1.KeyToPath{
+				{Key: caBundle.SecretKeyRef.Key, Path: RedisCABundleFileName},
+			},
+		},
+	}
+	return volume
+}
+
+func getRedisCABundle(ctx context.Context, cl client.Reader, defNamespace string, caBundle limitadorv1alpha1.CABundleSource) ([]byte, error) {
+	if caBundle.ConfigMapKeyRef != nil {
+		configMap := &v1.ConfigMap{}
+		if err := cl.Get(
+			ctx,
+			types.NamespacedName{
+				Name:      caBundle.ConfigMapKeyRef.Name,
+				Namespace: defNamespace,
+			},
+			configMap,
+		); err != nil {
+			// Must exist, so if it does not, also return err
+			return nil, err
+		}
+
+		// nil map behaves as empty map when reading
+		if value, ok := configMap.Data[caBundle.ConfigMapKeyRef.Key]; ok {
+			return []byte(value), nil
+		}
+		if value, ok := configMap.BinaryData[caBundle.ConfigMapKeyRef.Key]; ok {
+			return value, nil
+		}
+
+		return nil, fmt.Errorf("the Redis CA bundle ConfigMap %s doesn't have the `%s` field", caBundle.ConfigMapKeyRef.Name, caBundle.ConfigMapKeyRef.Key)
+	}
+
+	if caBundle.SecretKeyRef != nil {
+		secret := &v1.Secret{}
+		if err := cl.Get(
+			ctx,
+			types.NamespacedName{
+				Name:      caBundle.SecretKeyRef.Name,
+				Namespace: defNamespace,
+			},
+			secret,
+		); err != nil {
+			// Must exist, so if it does not, also return err
+			return nil, err
+		}
+
+		if value, ok := secret.Data[caBundle.SecretKeyRef.Key]; ok {
+			return value, nil
+		}
+
+		return nil, fmt.Errorf("the Redis CA bundle Secret %s doesn't have the `%s` field", caBundle.SecretKeyRef.Name, caBundle.SecretKeyRef.Key)
+	}
+
+	return nil, errors.New("the Redis CA bundle has no source set")
+}
@@ -0,0 +1,133 @@
+package limitador
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/log"
+)
+
+func TestRedisTLSOptions(t *testing.T) {
+	var (
+		namespace = "some-ns"
+	)
+
+	logger := log.Log.WithName("redis_tls_test")
+	ctx := logr.NewContext(context.Background(), logger)
+
+	clientFactory := func(objs []client.Object) client.Client {
+		return fake.NewClientBuilder().WithObjects(objs...).Build()
+	}
+
+	caConfigMap := &v1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-ca", Namespace: namespace},
+		Data:       map[string]string{"bundle.pem": "ca"},
+	}
+
+	t.Run("no tls", func(subT *testing.T) {
+		options, data, err := redisTLSOptions(ctx, clientFactory(nil), namespace, nil)
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, options, DeploymentStorageOptions{})
+		assert.Assert(subT, len(data) == 0)
+	})
+
+	t.Run("ca bundle configmap missing", func(subT *testing.T) {
+		tlsObj := &limitadorv1alpha1.RedisTLS{
+			CABundle: &limitadorv1alpha1.CABundleSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "redis-ca"},
+					Key:                  "bundle.pem",
+				},
+			},
+		}
+		_, _, err := redisTLSOptions(ctx, clientFactory(nil), namespace, tlsObj)
+		assert.Assert(subT, errors.IsNotFound(err))
+	})
+
+	t.Run("ca bundle key missing", func(subT *testing.T) {
+		tlsObj := &limitadorv1alpha1.RedisTLS{
+			CABundle: &limitadorv1alpha1.CABundleSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "redis-ca"},
+					Key:                  "other.pem",
+				},
+			},
+		}
+		_, _, err := redisTLSOptions(ctx, clientFactory([]client.Object{caConfigMap}), namespace, tlsObj)
+		assert.Error(subT, err, "the Redis CA bundle ConfigMap redis-ca doesn't have the `other.pem` field")
+	})
+
+	t.Run("ca bundle from configmap", func(subT *testing.T) {
+		tlsObj := &limitadorv1alpha1.RedisTLS{
+			CABundle: &limitadorv1alpha1.CABundleSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "redis-ca"},
+					Key:                  "bundle.pem",
+				},
+			},
+		}
+		options, data, err := redisTLSOptions(ctx, clientFactory([]client.Object{caConfigMap}), namespace, tlsObj)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(options.Args) == 0)
+		assert.DeepEqual(subT, options.EnvVar, []v1.EnvVar{
+			{Name: "SSL_CERT_FILE", Value: "/home/limitador/redis-tls/redis-ca-bundle/ca.crt"},
+		})
+		assert.DeepEqual(subT, options.VolumeMounts, []v1.VolumeMount{
+			{ReadOnly: true, Name: RedisCABundleVolumeName, MountPath: "/home/limitador/redis-tls/redis-ca-bundle"},
+		})
+		assert.Assert(subT, len(options.Volumes) == 1)
+		assert.DeepEqual(subT, options.Volumes[0].ConfigMap.Items, []v1.KeyToPath{{Key: "bundle.pem", Path: "ca.crt"}})
+		assert.DeepEqual(subT, data, map[string][]byte{
+			"redis-ca-bundle/ca.crt": []byte("ca"),
+		})
+	})
+
+	t.Run("ca bundle from secret", func(subT *testing.T) {
+		caSecret := &v1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: "redis-ca", Namespace: namespace},
+			Data:       helperGetSecretDataFromStringData(map[string]string{"ca.crt": "ca"}),
+		}
+		tlsObj := &limitadorv1alpha1.RedisTLS{
+			CABundle: &limitadorv1alpha1.CABundleSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "redis-ca"},
+					Key:                  "ca.crt",
+				},
+			},
+		}
+		options, _, err := redisTLSOptions(ctx, clientFactory([]client.Object{caSecret}), namespace, tlsObj)
+		assert.NilError(subT, err)
+		assert.Assert(subT, len(options.Volumes) == 1)
+		assert.Equal(subT, options.Volumes[0].Secret.SecretName, "redis-ca")
+	})
+
+	t.Run("ca bundle configmap names", func(subT *testing.T) {
+		limObj := &limitadorv1alpha1.Limitador{}
+		assert.Assert(subT, len(RedisCABundleConfigMapNames(limObj)) == 0)
+
+		limObj.Spec.Storage = &limitadorv1alpha1.Storage{
+			RedisCached: &limitadorv1alpha1.RedisCached{
+				TLS: &limitadorv1alpha1.RedisTLS{
+					CABundle: &limitadorv1alpha1.CABundleSource{
+						ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "redis-ca"},
+							Key:                  "bundle.pem",
+						},
+					},
+				},
+			},
+		}
+		assert.DeepEqual(subT, RedisCABundleConfigMapNames(limObj), []string{"redis-ca"})
+	})
+}
@@ -0,0 +1,79 @@
+package limitador
+
+import (
+	"sort"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient"
+)
+
+// ShadowLimitHits are the hits counted by a shadow limit in the current window of its counters
+type ShadowLimitHits struct {
+	Namespace string
+	Name      string
+	// Hits is the sum of the hits of the counters of the limit
+	Hits int64
+	// MaxCounterHits is the hits of the counter of the limit hit the most. The limit would reject
+	// requests once enforced when it exceeds the max value of the limit.
+	MaxCounterHits int64
+}
+
+// ShadowHits sums the hits of the counters of the shadow limits, by limit namespace and name.
+// The shadow limits of a namespace without name are summed together.
+type ShadowHits map[[2]string]*ShadowLimitHits
+
+// IsShadowLimit tells whether the limit loaded by limitador is a shadow limit, out of its rendered max value
+func IsShadowLimit(limit limitadorv1alpha1.RateLimit) bool {
+	return limit.MaxValue == ShadowMaxValue
+}
+
+// ShadowLimitNamespaces returns the sorted namespaces of the shadow limits
+func ShadowLimitNamespaces(limits []limitadorv1alpha1.RateLimit) []string {
+	namespaces := []string{}
+	for namespace, namespaceLimits := range LimitsByNamespace(limits) {
+		for _, limit := range namespaceLimits {
+			if IsShadowLimit(limit) {
+				namespaces = append(namespaces, namespace)
+				break
+			}
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// Add adds the hits of the counters of the shadow limits. The hits of a counter are the difference
+// between the rendered max value and its remaining value. The counters of the other limits are ignored.
+func (s ShadowHits) Add(counters []limitadorclient.Counter) {
+	for _, counter := range counters {
+		if !IsShadowLimit(counter.Limit) || counter.Remaining == nil {
+			continue
+		}
+
+		key := [2]string{counter.Limit.Namespace, counter.Limit.Name}
+		hits, ok := s[key]
+		if !ok {
+			hits = &ShadowLimitHits{Namespace: counter.Limit.Namespace, Name: counter.Limit.Name}
+			s[key] = hits
+		}
+
+		counterHits := max(0, ShadowMaxValue-*counter.Remaining)
+		hits.Hits += counterHits
+		hits.MaxCounterHits = max(hits.MaxCounterHits, counterHits)
+	}
+}
+
+// List returns the hits of the shadow limits sorted by namespace and name
+func (s ShadowHits) List() []ShadowLimitHits {
+	result := make([]ShadowLimitHits, 0, len(s))
+	for _, hits := range s {
+		result = append(result, *hits)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
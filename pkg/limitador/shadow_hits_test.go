@@ -0,0 +1,56 @@
+package limitador
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient"
+)
+
+func TestShadowLimitNamespaces(t *testing.T) {
+	limits := []limitadorv1alpha1.RateLimit{
+		{Namespace: "ns-b", MaxValue: ShadowMaxValue},
+		{Namespace: "ns-a", MaxValue: 10},
+		{Namespace: "ns-c", MaxValue: 10},
+		{Namespace: "ns-c", MaxValue: ShadowMaxValue},
+	}
+	assert.DeepEqual(t, ShadowLimitNamespaces(limits), []string{"ns-b", "ns-c"})
+}
+
+func TestShadowHits(t *testing.T) {
+	counter := func(name string, maxValue int, remaining *int64) limitadorclient.Counter {
+		return limitadorclient.Counter{
+			Limit:     limitadorv1alpha1.RateLimit{Namespace: "ns", Name: name, MaxValue: maxValue},
+			Remaining: remaining,
+		}
+	}
+
+	t.Run("hits summed by limit", func(subT *testing.T) {
+		hits := ShadowHits{}
+		hits.Add([]limitadorclient.Counter{
+			counter("b", ShadowMaxValue, ptr.To(int64(ShadowMaxValue-3))),
+			counter("a", ShadowMaxValue, ptr.To(int64(ShadowMaxValue-2))),
+			counter("a", ShadowMaxValue, ptr.To(int64(ShadowMaxValue-5))),
+		})
+		// e.g. the counters of another pod
+		hits.Add([]limitadorclient.Counter{
+			counter("a", ShadowMaxValue, ptr.To(int64(ShadowMaxValue-1))),
+		})
+		assert.DeepEqual(subT, hits.List(), []ShadowLimitHits{
+			{Namespace: "ns", Name: "a", Hits: 8, MaxCounterHits: 5},
+			{Namespace: "ns", Name: "b", Hits: 3, MaxCounterHits: 3},
+		})
+	})
+
+	t.Run("enforced limits ignored", func(subT *testing.T) {
+		hits := ShadowHits{}
+		hits.Add([]limitadorclient.Counter{
+			counter("enforced", 10, ptr.To(int64(4))),
+			counter("no-remaining", ShadowMaxValue, nil),
+		})
+		assert.Equal(subT, len(hits.List()), 0)
+	})
+}
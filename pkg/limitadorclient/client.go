@@ -0,0 +1,105 @@
+// Package limitadorclient queries the limitador HTTP API.
+package limitadorclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+)
+
+const DefaultTimeout = 5 * time.Second
+
+// Client queries the HTTP API of a limitador instance
+type Client interface {
+	// GetLimits returns the limits of the namespace loaded by the limitador instance
+	// listening at the endpoint, e.g. http://10.244.0.7:8080
+	GetLimits(ctx context.Context, endpoint, namespace string) ([]limitadorv1alpha1.RateLimit, error)
+
+	// GetCounters returns the counters of the limits of the namespace held by the limitador instance
+	// listening at the endpoint
+	GetCounters(ctx context.Context, endpoint, namespace string) ([]Counter, error)
+}
+
+// Counter is a counter of a limit, for a set of values of the variables of the limit
+type Counter struct {
+	Limit        limitadorv1alpha1.RateLimit `json:"limit"`
+	SetVariables map[string]string           `json:"set_variables,omitempty"`
+	// Remaining is the number of hits left in the current window
+	Remaining        *int64 `json:"remaining,omitempty"`
+	ExpiresInSeconds *int64 `json:"expires_in_seconds,omitempty"`
+}
+
+type client struct {
+	httpClient *http.Client
+}
+
+var _ Client = &client{}
+
+// New returns a Client sending the requests with the given http client.
+// A nil http client defaults to a client timing out after DefaultTimeout.
+func New(httpClient *http.Client) Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	return &client{httpClient: httpClient}
+}
+
+func (c *client) GetLimits(ctx context.Context, endpoint, namespace string) ([]limitadorv1alpha1.RateLimit, error) {
+	limits := []limitadorv1alpha1.RateLimit{}
+	if err := c.get(ctx, endpoint, "limits", namespace, &limits); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+func (c *client) GetCounters(ctx context.Context, endpoint, namespace string) ([]Counter, error) {
+	counters := []Counter{}
+	if err := c.get(ctx, endpoint, "counters", namespace, &counters); err != nil {
+		return nil, err
+	}
+
+	return counters, nil
+}
+
+// get decodes the JSON response to the request of the resource of the namespace, e.g. /limits/{namespace}
+func (c *client) get(ctx context.Context, endpoint, resource, namespace string, out any) error {
+	resourceURL, err := url.JoinPath(endpoint, resource, url.PathEscape(namespace))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resourceURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %d", resourceURL, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("GET %s: %w", resourceURL, err)
+	}
+
+	return nil
+}
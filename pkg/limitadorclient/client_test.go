@@ -0,0 +1,83 @@
+package limitadorclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/utils/ptr"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient/fake"
+)
+
+func TestGetLimits(t *testing.T) {
+	limits := []limitadorv1alpha1.RateLimit{
+		{Namespace: "ns-a", MaxValue: 10, Seconds: 60, Conditions: []string{"req.method == 'GET'"}, Variables: []string{"user_id"}, Name: "a"},
+	}
+
+	server := fake.NewServer(func(_ context.Context, host, namespace string) ([]limitadorv1alpha1.RateLimit, error) {
+		if fake.HostIP(host) == "10.0.0.2" {
+			return nil, errors.New("boom")
+		}
+		if namespace == "ns-a" {
+			return limits, nil
+		}
+		return nil, nil
+	})
+	defer server.Close()
+
+	t.Run("limits of the namespace", func(subT *testing.T) {
+		cl := limitadorclient.New(nil)
+		loaded, err := cl.GetLimits(context.Background(), server.URL, "ns-a")
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, loaded, limits)
+	})
+
+	t.Run("no limits", func(subT *testing.T) {
+		loaded, err := server.Client().GetLimits(context.Background(), "http://10.0.0.1:8080", "ns-b")
+		assert.NilError(subT, err)
+		assert.Equal(subT, len(loaded), 0)
+	})
+
+	t.Run("requests are routed to the fake server keeping the host", func(subT *testing.T) {
+		loaded, err := server.Client().GetLimits(context.Background(), "http://10.0.0.1:8080", "ns-a")
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, loaded, limits)
+
+		_, err = server.Client().GetLimits(context.Background(), "http://10.0.0.2:8080", "ns-a")
+		assert.ErrorContains(subT, err, "unexpected status 500")
+	})
+}
+
+func TestGetCounters(t *testing.T) {
+	counters := []limitadorclient.Counter{
+		{
+			Limit:            limitadorv1alpha1.RateLimit{Namespace: "ns-a", MaxValue: 10, Seconds: 60, Conditions: []string{}, Variables: []string{"user_id"}, Name: "a"},
+			SetVariables:     map[string]string{"user_id": "alice"},
+			Remaining:        ptr.To(int64(7)),
+			ExpiresInSeconds: ptr.To(int64(42)),
+		},
+	}
+	server := fake.NewServerWithCounters(nil, func(_ context.Context, _, namespace string) ([]limitadorclient.Counter, error) {
+		if namespace == "ns-a" {
+			return counters, nil
+		}
+		return nil, nil
+	})
+	defer server.Close()
+
+	t.Run("counters of the namespace", func(subT *testing.T) {
+		loaded, err := server.Client().GetCounters(context.Background(), "http://10.0.0.1:8080", "ns-a")
+		assert.NilError(subT, err)
+		assert.DeepEqual(subT, loaded, counters)
+	})
+
+	t.Run("no counters", func(subT *testing.T) {
+		loaded, err := server.Client().GetCounters(context.Background(), "http://10.0.0.1:8080", "ns-b")
+		assert.NilError(subT, err)
+		assert.Equal(subT, len(loaded), 0)
+	})
+}
@@ -0,0 +1,99 @@
+// Package fake provides a fake limitador HTTP API for tests.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	limitadorv1alpha1 "github.com/kuadrant/limitador-operator/api/v1alpha1"
+	"github.com/kuadrant/limitador-operator/pkg/limitadorclient"
+)
+
+// LimitsFunc returns the limits of the namespace loaded by the limitador instance
+// the request was sent to. The host is the one of the request, e.g. 10.244.0.7:8080
+type LimitsFunc func(ctx context.Context, host, namespace string) ([]limitadorv1alpha1.RateLimit, error)
+
+// CountersFunc returns the counters of the limits of the namespace held by the limitador instance
+// the request was sent to
+type CountersFunc func(ctx context.Context, host, namespace string) ([]limitadorclient.Counter, error)
+
+// Server serves the `/limits/{namespace}` and `/counters/{namespace}` endpoints of the limitador HTTP API
+type Server struct {
+	*httptest.Server
+	limitsFunc   LimitsFunc
+	countersFunc CountersFunc
+}
+
+// NewServer starts a fake limitador HTTP API serving the limits returned by limitsFunc, and no counters.
+// The caller must Close the server.
+func NewServer(limitsFunc LimitsFunc) *Server {
+	return NewServerWithCounters(limitsFunc, nil)
+}
+
+// NewServerWithCounters starts a fake limitador HTTP API serving the limits returned by limitsFunc,
+// and the counters returned by countersFunc. The caller must Close the server.
+func NewServerWithCounters(limitsFunc LimitsFunc, countersFunc CountersFunc) *Server {
+	s := &Server{limitsFunc: limitsFunc, countersFunc: countersFunc}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /limits/{namespace}", s.getLimits)
+	mux.HandleFunc("GET /counters/{namespace}", s.getCounters)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *Server) getLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := s.limitsFunc(r.Context(), r.Host, r.PathValue("namespace"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if limits == nil {
+		limits = []limitadorv1alpha1.RateLimit{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(limits)
+}
+
+func (s *Server) getCounters(w http.ResponseWriter, r *http.Request) {
+	counters := []limitadorclient.Counter{}
+	if s.countersFunc != nil {
+		var err error
+		counters, err = s.countersFunc(r.Context(), r.Host, r.PathValue("namespace"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if counters == nil {
+		counters = []limitadorclient.Counter{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(counters)
+}
+
+// Client returns a client sending every request to the fake server, whatever the endpoint.
+// The original host is kept in the request, so the LimitsFunc can tell the instances apart.
+func (s *Server) Client() limitadorclient.Client {
+	transport := s.Server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, s.Listener.Addr().String())
+	}
+
+	return limitadorclient.New(&http.Client{Transport: transport, Timeout: limitadorclient.DefaultTimeout})
+}
+
+// HostIP returns the IP of the host, e.g. 10.244.0.7 for 10.244.0.7:8080
+func HostIP(host string) string {
+	if ip, _, err := net.SplitHostPort(host); err == nil {
+		return ip
+	}
+	return strings.Trim(host, "[]")
+}
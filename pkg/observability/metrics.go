@@ -0,0 +1,243 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package observability
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "limitador_operator"
+
+// Reconciliation phases of the Limitador objects, reported by the reconcile errors metric
+const (
+	PhaseService        = "service"
+	PhasePVC            = "pvc"
+	PhaseManagedRedis   = "managed_redis"
+	PhaseDeployment     = "deployment"
+	PhaseConfigMap      = "configmap"
+	PhasePDB            = "pdb"
+	PhaseHPA            = "hpa"
+	PhaseNetworkPolicy  = "network_policy"
+	PhaseMonitoring     = "monitoring"
+	PhasePodAnnotations = "pod_annotations"
+	PhaseStatus         = "status"
+)
+
+// LimitadorMetrics is the state of a Limitador object reported by the operator metrics
+type LimitadorMetrics struct {
+	StorageType string
+	Ready       bool
+	Limits      int32
+	// LimitsLastUpdate is the time the limits last changed. Zero when unknown.
+	LimitsLastUpdate time.Time
+	// LaggingPods is the number of pods not running the current limits ConfigMap version
+	LaggingPods int32
+	// ShadowHits reads the hits of the shadow limits at scrape time, as they change between the reconciliations.
+	// Nil when the hits are not read.
+	ShadowHits ShadowHitsFunc
+}
+
+// ShadowLimitHits are the hits counted by a shadow limit of a Limitador object
+type ShadowLimitHits struct {
+	LimitNamespace string
+	LimitName      string
+	Hits           int64
+	MaxCounterHits int64
+}
+
+// ShadowHitsFunc reads the hits of the shadow limits of a Limitador object. The context is canceled
+// after ShadowHitsTimeout.
+type ShadowHitsFunc func(ctx context.Context) []ShadowLimitHits
+
+// ShadowHitsTimeout bounds the time spent reading the hits of the shadow limits of a Limitador object on scrape
+const ShadowHitsTimeout = 5 * time.Second
+
+var (
+	reconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_errors_total",
+			Help:      "Number of errors reconciling Limitador objects, by reconciliation phase",
+		},
+		[]string{"phase"},
+	)
+
+	limitadorCollector = newLimitadorMetricsCollector()
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileErrors, limitadorCollector)
+}
+
+// RecordReconcileError counts a reconciliation error of the phase
+func RecordReconcileError(phase string) {
+	reconcileErrors.WithLabelValues(phase).Inc()
+}
+
+// SetLimitadorMetrics records the state of the Limitador object
+func SetLimitadorMetrics(key types.NamespacedName, state LimitadorMetrics) {
+	limitadorCollector.set(key, state)
+}
+
+// DeleteLimitadorMetrics stops reporting the Limitador object
+func DeleteLimitadorMetrics(key types.NamespacedName) {
+	limitadorCollector.delete(key)
+}
+
+// limitadorMetricsCollector reports the state recorded for each Limitador object at scrape time,
+// so the age of the limits is always current and deleted objects are not reported anymore
+type limitadorMetricsCollector struct {
+	mutex      sync.RWMutex
+	limitadors map[types.NamespacedName]LimitadorMetrics
+
+	limitadorsDesc  *prometheus.Desc
+	readyDesc       *prometheus.Desc
+	limitsDesc      *prometheus.Desc
+	limitsAgeDesc   *prometheus.Desc
+	laggingPodsDesc *prometheus.Desc
+
+	shadowHitsDesc           *prometheus.Desc
+	shadowMaxCounterHitsDesc *prometheus.Desc
+}
+
+func newLimitadorMetricsCollector() *limitadorMetricsCollector {
+	instanceLabels := []string{"namespace", "name"}
+	shadowLimitLabels := []string{"namespace", "name", "limit_namespace", "limit_name"}
+	return &limitadorMetricsCollector{
+		limitadors: map[types.NamespacedName]LimitadorMetrics{},
+		limitadorsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "limitadors"),
+			"Number of Limitador objects, by storage type",
+			[]string{"storage"}, nil,
+		),
+		readyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "limitador", "ready"),
+			"Whether the Ready condition of the Limitador object is true",
+			instanceLabels, nil,
+		),
+		limitsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "limitador", "limits"),
+			"Number of limits of the Limitador object",
+			instanceLabels, nil,
+		),
+		limitsAgeDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "limitador", "limits_last_update_age_seconds"),
+			"Time since the limits of the Limitador object last changed",
+			instanceLabels, nil,
+		),
+		laggingPodsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "limitador", "lagging_pods"),
+			"Number of pods of the Limitador object not running the current limits ConfigMap version",
+			instanceLabels, nil,
+		),
+		shadowHitsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "limitador", "shadow_limit_hits"),
+			"Hits counted by the shadow limit in the current window of its counters, summed over its counters",
+			shadowLimitLabels, nil,
+		),
+		shadowMaxCounterHitsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "limitador", "shadow_limit_max_counter_hits"),
+			"Hits counted by the counter of the shadow limit hit the most in its current window",
+			shadowLimitLabels, nil,
+		),
+	}
+}
+
+func (c *limitadorMetricsCollector) set(key types.NamespacedName, state LimitadorMetrics) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.limitadors[key] = state
+}
+
+func (c *limitadorMetricsCollector) delete(key types.NamespacedName) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.limitadors, key)
+}
+
+func (c *limitadorMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.limitadorsDesc
+	ch <- c.readyDesc
+	ch <- c.limitsDesc
+	ch <- c.limitsAgeDesc
+	ch <- c.laggingPodsDesc
+	ch <- c.shadowHitsDesc
+	ch <- c.shadowMaxCounterHitsDesc
+}
+
+func (c *limitadorMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	// The shadow hits are read concurrently, each Limitador object within ShadowHitsTimeout,
+	// waited for once the lock is released
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	storageCounts := map[string]int{}
+	for key, state := range c.limitadors {
+		storageCounts[state.StorageType]++
+
+		if state.ShadowHits != nil {
+			wg.Add(1)
+			go func(key types.NamespacedName, shadowHits ShadowHitsFunc) {
+				defer wg.Done()
+				c.collectShadowHits(ch, key, shadowHits)
+			}(key, state.ShadowHits)
+		}
+
+		ready := 0.0
+		if state.Ready {
+			ready = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.readyDesc, prometheus.GaugeValue, ready, key.Namespace, key.Name)
+		ch <- prometheus.MustNewConstMetric(c.limitsDesc, prometheus.GaugeValue, float64(state.Limits), key.Namespace, key.Name)
+		ch <- prometheus.MustNewConstMetric(c.laggingPodsDesc, prometheus.GaugeValue, float64(state.LaggingPods), key.Namespace, key.Name)
+		if !state.LimitsLastUpdate.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.limitsAgeDesc, prometheus.GaugeValue,
+				time.Since(state.LimitsLastUpdate).Seconds(), key.Namespace, key.Name)
+		}
+	}
+
+	storageTypes := make([]string, 0, len(storageCounts))
+	for storageType := range storageCounts {
+		storageTypes = append(storageTypes, storageType)
+	}
+	sort.Strings(storageTypes)
+	for _, storageType := range storageTypes {
+		ch <- prometheus.MustNewConstMetric(c.limitadorsDesc, prometheus.GaugeValue, float64(storageCounts[storageType]), storageType)
+	}
+}
+
+func (c *limitadorMetricsCollector) collectShadowHits(ch chan<- prometheus.Metric, key types.NamespacedName, shadowHits ShadowHitsFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), ShadowHitsTimeout)
+	defer cancel()
+
+	for _, hits := range shadowHits(ctx) {
+		ch <- prometheus.MustNewConstMetric(c.shadowHitsDesc, prometheus.GaugeValue, float64(hits.Hits),
+			key.Namespace, key.Name, hits.LimitNamespace, hits.LimitName)
+		ch <- prometheus.MustNewConstMetric(c.shadowMaxCounterHitsDesc, prometheus.GaugeValue, float64(hits.MaxCounterHits),
+			key.Namespace, key.Name, hits.LimitNamespace, hits.LimitName)
+	}
+}
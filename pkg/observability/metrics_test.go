@@ -0,0 +1,88 @@
+package observability
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestLimitadorMetricsCollector(t *testing.T) {
+	t.Run("reports the recorded limitador instances", func(subT *testing.T) {
+		collector := newLimitadorMetricsCollector()
+		collector.set(types.NamespacedName{Namespace: "ns-a", Name: "a"}, LimitadorMetrics{StorageType: "memory", Ready: true, Limits: 3})
+		collector.set(types.NamespacedName{Namespace: "ns-b", Name: "b"}, LimitadorMetrics{StorageType: "redis", LaggingPods: 2})
+		collector.set(types.NamespacedName{Namespace: "ns-c", Name: "c"}, LimitadorMetrics{StorageType: "redis"})
+
+		expected := `
+# HELP limitador_operator_limitador_lagging_pods Number of pods of the Limitador object not running the current limits ConfigMap version
+# TYPE limitador_operator_limitador_lagging_pods gauge
+limitador_operator_limitador_lagging_pods{name="a",namespace="ns-a"} 0
+limitador_operator_limitador_lagging_pods{name="b",namespace="ns-b"} 2
+limitador_operator_limitador_lagging_pods{name="c",namespace="ns-c"} 0
+# HELP limitador_operator_limitador_limits Number of limits of the Limitador object
+# TYPE limitador_operator_limitador_limits gauge
+limitador_operator_limitador_limits{name="a",namespace="ns-a"} 3
+limitador_operator_limitador_limits{name="b",namespace="ns-b"} 0
+limitador_operator_limitador_limits{name="c",namespace="ns-c"} 0
+# HELP limitador_operator_limitador_ready Whether the Ready condition of the Limitador object is true
+# TYPE limitador_operator_limitador_ready gauge
+limitador_operator_limitador_ready{name="a",namespace="ns-a"} 1
+limitador_operator_limitador_ready{name="b",namespace="ns-b"} 0
+limitador_operator_limitador_ready{name="c",namespace="ns-c"} 0
+# HELP limitador_operator_limitadors Number of Limitador objects, by storage type
+# TYPE limitador_operator_limitadors gauge
+limitador_operator_limitadors{storage="memory"} 1
+limitador_operator_limitadors{storage="redis"} 2
+`
+		assert.NilError(subT, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	})
+
+	t.Run("reports the age of the limits", func(subT *testing.T) {
+		collector := newLimitadorMetricsCollector()
+		collector.set(types.NamespacedName{Namespace: "ns", Name: "a"}, LimitadorMetrics{LimitsLastUpdate: time.Now().Add(-time.Minute)})
+
+		assert.Equal(subT, testutil.CollectAndCount(collector, "limitador_operator_limitador_limits_last_update_age_seconds"), 1)
+	})
+
+	t.Run("reports the hits of the shadow limits read on scrape", func(subT *testing.T) {
+		collector := newLimitadorMetricsCollector()
+		collector.set(types.NamespacedName{Namespace: "ns", Name: "a"}, LimitadorMetrics{
+			ShadowHits: func(ctx context.Context) []ShadowLimitHits {
+				_, hasDeadline := ctx.Deadline()
+				assert.Assert(subT, hasDeadline)
+				return []ShadowLimitHits{{LimitNamespace: "toystore", LimitName: "post", Hits: 12, MaxCounterHits: 7}}
+			},
+		})
+
+		expected := `
+# HELP limitador_operator_limitador_shadow_limit_hits Hits counted by the shadow limit in the current window of its counters, summed over its counters
+# TYPE limitador_operator_limitador_shadow_limit_hits gauge
+limitador_operator_limitador_shadow_limit_hits{limit_name="post",limit_namespace="toystore",name="a",namespace="ns"} 12
+# HELP limitador_operator_limitador_shadow_limit_max_counter_hits Hits counted by the counter of the shadow limit hit the most in its current window
+# TYPE limitador_operator_limitador_shadow_limit_max_counter_hits gauge
+limitador_operator_limitador_shadow_limit_max_counter_hits{limit_name="post",limit_namespace="toystore",name="a",namespace="ns"} 7
+`
+		assert.NilError(subT, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"limitador_operator_limitador_shadow_limit_hits", "limitador_operator_limitador_shadow_limit_max_counter_hits"))
+	})
+
+	t.Run("deleted instances are not reported", func(subT *testing.T) {
+		collector := newLimitadorMetricsCollector()
+		key := types.NamespacedName{Namespace: "ns", Name: "a"}
+		collector.set(key, LimitadorMetrics{StorageType: "disk"})
+		collector.delete(key)
+
+		assert.Equal(subT, testutil.CollectAndCount(collector), 0)
+	})
+}
+
+func TestRecordReconcileError(t *testing.T) {
+	before := testutil.ToFloat64(reconcileErrors.WithLabelValues(PhasePVC))
+	RecordReconcileError(PhasePVC)
+	assert.Equal(t, testutil.ToFloat64(reconcileErrors.WithLabelValues(PhasePVC)), before+1)
+}
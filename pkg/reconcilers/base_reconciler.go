@@ -151,8 +151,9 @@ func (b *BaseReconciler) ReconcilePodDisruptionBudget(ctx context.Context, desir
 	return b.ReconcileResource(ctx, desired)
 }
 
-// ReconcilePersistentVolumeClaim handles PVC reconciliation with create-only semantics.
-// PVCs cannot be updated after creation (immutable fields), so we only create if not exists.
+// ReconcilePersistentVolumeClaim handles PVC reconciliation with create-only semantics for the spec.
+// PVCs cannot be updated after creation (immutable fields), so we only create if not exists,
+// adopt the released ones and sync the annotations of the owned ones.
 func (b *BaseReconciler) ReconcilePersistentVolumeClaim(ctx context.Context, desired *corev1.PersistentVolumeClaim) error {
 	logger, err := logr.FromContext(ctx)
 	if err != nil {
@@ -181,6 +182,37 @@ func (b *BaseReconciler) ReconcilePersistentVolumeClaim(ctx context.Context, des
 		}
 		return err
 	}
+	update := false
+	ownerReference, controller := metav1.GetControllerOf(desired), metav1.GetControllerOf(obj)
+	// Adopt the PVC released by a previous owner of the same name, retaining its data.
+	// Other PVCs of the same name are not adopted.
+	if ownerReference != nil && controller == nil && obj.GetAnnotations()[helpers.ReleasedByAnnotation] == ownerReference.Name {
+		obj.OwnerReferences = append(obj.OwnerReferences, *ownerReference)
+		delete(obj.Annotations, helpers.ReleasedByAnnotation)
+		controller = ownerReference
+		update = true
+	}
+	// The annotations are mutable, unlike the spec, so they are synced on the owned PVCs
+	owned := ownerReference != nil && controller != nil && controller.UID == ownerReference.UID
+	for key, value := range desired.GetAnnotations() {
+		if existing, ok := obj.GetAnnotations()[key]; owned && (!ok || existing != value) {
+			if obj.Annotations == nil {
+				obj.Annotations = map[string]string{}
+			}
+			obj.Annotations[key] = value
+			update = true
+		}
+	}
+	if update {
+		err := b.UpdateResource(ctx, obj)
+		span := trace.SpanFromContext(ctx)
+		if err == nil {
+			observability.RecordResourceApplied(span)
+		} else {
+			observability.RecordError(span, err, "failed to update PVC")
+		}
+		return err
+	}
 	// PVC already exists, log and skip (PVCs are immutable after creation)
 	logger.V(1).Info("pvc already exists, skipping update", "name", desired.GetName(), "namespace", desired.GetNamespace())
 	span := trace.SpanFromContext(ctx)